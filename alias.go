@@ -0,0 +1,82 @@
+package fsb
+
+import (
+	"fmt"
+)
+
+// AliasContainer
+// This structure represents an item of the select list with an alias, such as users.id AS user_id.
+// In the select list it is rendered as `expression AS alias`.
+// Anywhere else, such as in Order, GroupBy or the conditions of Having, only the alias is rendered,
+// so the same value can be used to refer to the item.
+type AliasContainer struct {
+	expr  interface{}
+	alias string
+}
+
+// LiteralContainer
+// This structure represents a literal value in the select list, such as 'active' or 1.
+type LiteralContainer struct {
+	value interface{}
+}
+
+// Alias is a function that gives an item of the select list an alias.
+// The item can be a column name, a *ColumnContainer, a function call, a CASE expression,
+// a literal or a *SelectContainer, which is rendered as a scalar subquery.
+//
+// Example usage:
+//
+//	orderCount := Alias(Select("COUNT(*)").From(Table("orders")).Where(Eq("orders.user_id", users.Col("id"))), "order_count")
+//	Select(users.Col("id").As("user_id"), orderCount).From(users).OrderDe(orderCount)
+//	// SELECT users.id AS user_id, (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id) AS order_count
+//	// FROM users ORDER BY order_count DESC
+func Alias(expr interface{}, alias string) *AliasContainer {
+	return &AliasContainer{
+		expr:  expr,
+		alias: alias,
+	}
+}
+
+// Name returns the alias.
+func (a *AliasContainer) Name() string {
+	return a.alias
+}
+
+// Literal is a function that creates a literal value for the select list.
+// Unlike a string given to Select, which is used as a column name, the value is rendered quoted.
+//
+// Example usage:
+//
+//	Select("id", Alias(Literal("user"), "kind")) // SELECT id, 'user' AS kind
+func Literal(value interface{}) *LiteralContainer {
+	return &LiteralContainer{
+		value: value,
+	}
+}
+
+// selectItem renders an item of the select list.
+// Strings are written as they are, so that expressions such as COUNT(*) keep working.
+func (r *renderer) selectItem(item interface{}) string {
+	switch t := item.(type) {
+	case string:
+		return t
+	case *AliasContainer:
+		if !identPattern.MatchString(t.alias) {
+			r.fail(CodeInvalidIdentifier, "invalid alias %q", t.alias)
+			return ""
+		}
+		return fmt.Sprintf("%s AS %s", r.selectItem(t.expr), t.alias)
+	}
+
+	itemStr := r.column(item)
+	if itemStr == "" {
+		r.fail(CodeInvalidValue, "unsupported select item %T", item)
+	}
+
+	return itemStr
+}
+
+// subquery renders a SelectContainer used as an operand, wrapped in parentheses.
+func (r *renderer) subquery(s *SelectContainer) string {
+	return fmt.Sprintf("(%s)", s.createSQL(r))
+}
@@ -0,0 +1,255 @@
+package fsb
+
+import (
+	"database/sql/driver"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+)
+
+// Seek
+// It switches the SelectContainer to keyset (seek) pagination.
+// The values are the ordering values of the last row of the previous page,
+// given in the same order as the columns set via Order, OrderA and OrderDe.
+// When the statement is generated, a condition selecting the rows after that row is added to the WHERE clause.
+// If every column is ordered in the same direction and the dialect supports row values,
+// the condition is written as a row comparison such as (a, b) > (1, 2);
+// otherwise it is expanded to (a > 1 OR (a = 1 AND b > 2)), which also handles mixed ASC/DESC orders.
+// The ordering columns should end with a unique column, and must not contain NULL values.
+func (s *SelectContainer) Seek(values ...interface{}) *SelectContainer {
+	c := s.Clone()
+	c.seek = values
+
+	return c
+}
+
+// After
+// It is the same as Seek, but takes the values from a cursor token created by EncodeCursor.
+// An empty token selects the first page.
+func (s *SelectContainer) After(cursor string) *SelectContainer {
+	if cursor == "" {
+		return s.Clone()
+	}
+
+	values, err := DecodeCursor(cursor)
+	if err != nil {
+		c := s.Clone()
+		c.errs = append(c.errs, &BuildError{Statement: "SELECT", Clause: "WHERE", Code: CodeInvalidCursor, Err: err})
+		return c
+	}
+
+	return s.Seek(values...)
+}
+
+// cursorValue is an ordering value in a cursor token, tagged with its type so that DecodeCursor can restore it.
+type cursorValue struct {
+	Type  string          `json:"t"`
+	Value json.RawMessage `json:"v,omitempty"`
+}
+
+// EncodeCursor is a function that encodes the ordering values of a row into an opaque cursor token
+// that can be returned in API responses and passed back to After.
+// The values may be nil, strings, booleans, integers, floating point numbers, time.Time and []byte,
+// pointers to them and driver.Valuer implementations returning them. Any other type is an error.
+func EncodeCursor(values ...interface{}) (string, error) {
+	tagged := make([]cursorValue, len(values))
+	for i, v := range values {
+		cv, err := encodeCursorValue(v)
+		if err != nil {
+			return "", fmt.Errorf("invalid cursor value: %w", err)
+		}
+		tagged[i] = cv
+	}
+
+	data, err := json.Marshal(tagged)
+	if err != nil {
+		return "", fmt.Errorf("invalid cursor value: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+// encodeCursorValue tags a value with its type.
+func encodeCursorValue(v interface{}) (cursorValue, error) {
+	if valuer, ok := v.(driver.Valuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return cursorValue{Type: "null"}, nil
+		}
+		dv, err := valuer.Value()
+		if err != nil {
+			return cursorValue{}, err
+		}
+		v = dv
+	}
+
+	var typ string
+	var value interface{}
+	switch t := v.(type) {
+	case nil:
+		return cursorValue{Type: "null"}, nil
+	case time.Time:
+		typ, value = "time", t.Format(time.RFC3339Nano)
+	case []byte:
+		typ, value = "bytes", t
+	default:
+		rv := reflect.ValueOf(v)
+		switch rv.Kind() {
+		case reflect.Ptr:
+			if rv.IsNil() {
+				return cursorValue{Type: "null"}, nil
+			}
+			return encodeCursorValue(rv.Elem().Interface())
+		case reflect.String:
+			typ, value = "string", rv.String()
+		case reflect.Bool:
+			typ, value = "bool", rv.Bool()
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			typ, value = "int", rv.Int()
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			typ, value = "uint", rv.Uint()
+		case reflect.Float32, reflect.Float64:
+			typ, value = "float", rv.Float()
+		default:
+			return cursorValue{}, fmt.Errorf("unsupported type %T", v)
+		}
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		return cursorValue{}, err
+	}
+
+	return cursorValue{Type: typ, Value: data}, nil
+}
+
+// DecodeCursor is a function that decodes a cursor token created by EncodeCursor back into the ordering values.
+// Values are restored to their type: integers are returned as int, unsigned integers as uint64,
+// other numbers as float64, timestamps as time.Time and binary values as []byte.
+func DecodeCursor(cursor string) ([]interface{}, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	var tagged []cursorValue
+	if err := json.Unmarshal(data, &tagged); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidCursor, err)
+	}
+
+	values := make([]interface{}, len(tagged))
+	for i, cv := range tagged {
+		v, err := decodeCursorValue(cv)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s value: %w", ErrInvalidCursor, cv.Type, err)
+		}
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// decodeCursorValue restores a value from its tagged form.
+func decodeCursorValue(cv cursorValue) (interface{}, error) {
+	switch cv.Type {
+	case "null":
+		return nil, nil
+	case "string":
+		var v string
+		err := json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "bool":
+		var v bool
+		err := json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "int":
+		var v int
+		err := json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "uint":
+		var v uint64
+		err := json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "float":
+		var v float64
+		err := json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "bytes":
+		var v []byte
+		err := json.Unmarshal(cv.Value, &v)
+		return v, err
+	case "time":
+		var v string
+		if err := json.Unmarshal(cv.Value, &v); err != nil {
+			return nil, err
+		}
+		return time.Parse(time.RFC3339Nano, v)
+	default:
+		return nil, errors.New("unknown type")
+	}
+}
+
+// createSeekCondition builds the keyset pagination condition from the ordering columns and the Seek values.
+// It returns nil when Seek has not been used.
+func (s *SelectContainer) createSeekCondition(r *renderer) *Expression {
+	if len(s.seek) == 0 {
+		return nil
+	}
+
+	var columns []interface{}
+	var orderTypes []int
+	for _, order := range s.orders {
+		for i, column := range order.columns {
+			columns = append(columns, column)
+			// ORDER BY a, b DESC only applies DESC to b, so the leading columns of an order are ascending.
+			if i == len(order.columns)-1 {
+				orderTypes = append(orderTypes, order.orderType)
+			} else {
+				orderTypes = append(orderTypes, asc)
+			}
+		}
+	}
+
+	if len(columns) != len(s.seek) {
+		r.fail(CodeInvalidValue, "seek needs %d values for the order columns but got %d", len(columns), len(s.seek))
+		return nil
+	}
+
+	sameDirection := true
+	for _, orderType := range orderTypes {
+		if orderType != orderTypes[0] {
+			sameDirection = false
+		}
+	}
+
+	if len(columns) > 1 && sameDirection && r.dialect != Generic && r.dialect != SQLServer {
+		return createCondition(rowValue(columns), rowValue(s.seek), seekSign(orderTypes[0]))
+	}
+
+	var branches []*Expression
+	for i := range columns {
+		var conditions []*Expression
+		for k := 0; k < i; k++ {
+			conditions = append(conditions, Eq(columns[k], s.seek[k]))
+		}
+		conditions = append(conditions, createCondition(columns[i], s.seek[i], seekSign(orderTypes[i])))
+		branches = append(branches, And(conditions...))
+	}
+
+	if len(branches) == 1 {
+		return branches[0]
+	}
+
+	return Group(Or(branches...))
+}
+
+// seekSign returns the comparison that selects the rows after the cursor for the given order direction.
+func seekSign(orderType int) string {
+	if orderType == desc {
+		return "<"
+	}
+
+	return ">"
+}
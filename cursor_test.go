@@ -0,0 +1,146 @@
+package fsb_test
+
+import (
+	"fsb"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CursorSuite struct {
+	suite.Suite
+}
+
+// Test_SeekSingle tests keyset pagination on a single ordering column.
+func (s *CursorSuite) Test_SeekSingle() {
+	sb := fsb.Select().From(fsb.Table("users")).OrderA("id").Limit(20).Seek(100)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users WHERE id > 100 ORDER BY id ASC LIMIT 20;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SeekExpanded tests that mixed ASC/DESC orders are expanded into OR conditions.
+func (s *CursorSuite) Test_SeekExpanded() {
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		Where(fsb.Eq("deleted", false)).
+		OrderDe("created_at").
+		OrderA("id").
+		Seek("2024-01-01", 7)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users WHERE deleted = false AND "+
+			"(created_at < '2024-01-01' OR (created_at = '2024-01-01' AND id > 7)) "+
+			"ORDER BY created_at DESC, id ASC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SeekRowValue tests that orders in one direction use a row comparison on dialects that support it.
+func (s *CursorSuite) Test_SeekRowValue() {
+	user := fsb.Table("users")
+
+	sb := fsb.Select().
+		From(user).
+		Dialect(fsb.PostgreSQL).
+		OrderDe(user.Col("score")).
+		OrderDe(user.Col("id")).
+		Seek(10, 3)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users WHERE (users.score, users.id) < (10, 3) ORDER BY users.score DESC, users.id DESC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SeekMismatch tests that the number of cursor values must match the ordering columns.
+func (s *CursorSuite) Test_SeekMismatch() {
+	sql, err := fsb.Select().From(fsb.Table("users")).OrderA("id").Seek(1, 2).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_Cursor tests that a cursor token round-trips through EncodeCursor and After.
+func (s *CursorSuite) Test_Cursor() {
+	token, err := fsb.EncodeCursor("tanaka", 42)
+	assert.Nil(s.T(), err)
+
+	values, err := fsb.DecodeCursor(token)
+	assert.Nil(s.T(), err)
+	assert.Equal(s.T(), []interface{}{"tanaka", 42}, values)
+
+	sql, err := fsb.Select().From(fsb.Table("users")).OrderA("name", "id").After(token).ToSQL()
+	assert.Equal(s.T(), "SELECT * FROM users WHERE (name > 'tanaka' OR (name = 'tanaka' AND id > 42)) ORDER BY name, id ASC;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).OrderA("id").After("").ToSQL()
+	assert.Equal(s.T(), "SELECT * FROM users ORDER BY id ASC;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_CursorTypes tests that time.Time and []byte values keep their type through a cursor token.
+func (s *CursorSuite) Test_CursorTypes() {
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 600000, time.UTC)
+
+	token, err := fsb.EncodeCursor(createdAt, []byte{0xbe, 0xef}, nil)
+	assert.Nil(s.T(), err)
+
+	values, err := fsb.DecodeCursor(token)
+	assert.Nil(s.T(), err)
+	assert.True(s.T(), createdAt.Equal(values[0].(time.Time)))
+	assert.Equal(s.T(), []byte{0xbe, 0xef}, values[1])
+	assert.Nil(s.T(), values[2])
+
+	token, err = fsb.EncodeCursor(createdAt, 7)
+	assert.Nil(s.T(), err)
+
+	sql, err := fsb.Select().From(fsb.Table("events")).OrderA("created_at", "id").After(token).Dialect(fsb.MySQL).ToSQL()
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM events WHERE (created_at, id) > ('2024-01-02 03:04:05.0006', 7) ORDER BY created_at, id ASC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.EncodeCursor(struct{}{})
+	assert.NotNil(s.T(), err)
+}
+
+// Test_CursorInvalid tests that a broken cursor token is reported as an error.
+func (s *CursorSuite) Test_CursorInvalid() {
+	sql, err := fsb.Select().From(fsb.Table("users")).OrderA("id").After("!!").ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_SeekMultiColumnOrder tests that only the last column of a multi-column order follows its direction.
+func (s *CursorSuite) Test_SeekMultiColumnOrder() {
+	sb := fsb.Select().From(fsb.Table("users")).Dialect(fsb.PostgreSQL).OrderDe("score", "id").Seek(10, 3)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users WHERE (score > 10 OR (score = 10 AND id < 3)) ORDER BY score, id DESC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+func TestCursorSuite(t *testing.T) {
+	suite.Run(t, new(CursorSuite))
+}
@@ -7,9 +7,12 @@ import (
 )
 
 type DeleteContainer struct {
-	table *TableContainer
-	where *Expression
-	errs  []error
+	table    *TableContainer
+	where    *Expression
+	allRows  bool
+	dialect  Dialect
+	encoders *Encoders
+	errs     []error
 }
 
 // Delete is a function that initializes a new DeleteContainer instance.
@@ -21,11 +24,101 @@ func Delete(table *TableContainer) *DeleteContainer {
 }
 
 // Where sets the conditions for the deletion operation.
-// It takes a pointer to an Expression and returns a pointer to the DeleteContainer instance.
+// It takes a pointer to an Expression and returns a pointer to a copy of the DeleteContainer instance.
 func (d *DeleteContainer) Where(conditions *Expression) *DeleteContainer {
-	d.where = conditions
+	c := d.Clone()
+	c.where = conditions
 
-	return d
+	return c
+}
+
+// AndWhere adds the conditions to the WHERE clause of the deletion operation with the AND operator.
+// When no WHERE clause has been set yet, the conditions become the WHERE clause.
+// A nil expression is ignored, so optional filters can be chained directly.
+func (d *DeleteContainer) AndWhere(conditions *Expression) *DeleteContainer {
+	c := d.Clone()
+	c.where = And(c.where, conditions)
+
+	return c
+}
+
+// OrWhere adds the conditions to the WHERE clause of the deletion operation with the OR operator.
+// A nil expression is ignored in the same way as AndWhere.
+func (d *DeleteContainer) OrWhere(conditions *Expression) *DeleteContainer {
+	c := d.Clone()
+	c.where = Or(c.where, conditions)
+
+	return c
+}
+
+// WhereIf adds the conditions to the WHERE clause with the AND operator only when cond is true.
+func (d *DeleteContainer) WhereIf(cond bool, conditions *Expression) *DeleteContainer {
+	if !cond {
+		return d.Clone()
+	}
+
+	return d.AndWhere(conditions)
+}
+
+// Clone returns a deep copy of the DeleteContainer.
+func (d *DeleteContainer) Clone() *DeleteContainer {
+	c := *d
+
+	c.table = d.table.clone()
+	c.where = d.where.Clone()
+	c.errs = append([]error(nil), d.errs...)
+
+	return &c
+}
+
+// Dialect sets the database the delete operation is generated for.
+// Conditions whose syntax differs between databases are rendered for this dialect.
+func (d *DeleteContainer) Dialect(dialect Dialect) *DeleteContainer {
+	c := d.Clone()
+	c.dialect = dialect
+
+	return c
+}
+
+// Encoders sets the registry of encoders used for the values of the conditions.
+// Without it, DefaultEncoders is used.
+func (d *DeleteContainer) Encoders(encoders *Encoders) *DeleteContainer {
+	c := d.Clone()
+	c.encoders = encoders
+
+	return c
+}
+
+// AllRows allows the delete operation to delete every row of the table.
+// Without it, Validate rejects a DELETE without a WHERE clause or with a condition that is always true, such as 1 = 1.
+func (d *DeleteContainer) AllRows() *DeleteContainer {
+	c := d.Clone()
+	c.allRows = true
+
+	return c
+}
+
+// Validate checks the structure of the delete operation without generating it.
+// It returns the errors recorded by the builder methods together with a *BuildError
+// when the table is missing, or when the operation would delete every row without AllRows.
+// ToSQL reports the same errors together with the ones found while rendering WHERE.
+func (d *DeleteContainer) Validate() error {
+	return errors.Join(d.validate()...)
+}
+
+// validate returns the errors recorded by the builder methods and the structural errors of the operation.
+func (d *DeleteContainer) validate() []error {
+	errs := append([]error(nil), d.errs...)
+
+	if d.table == nil || d.table.name == "" {
+		errs = append(errs, buildError("DELETE", "FROM", CodeMissingClause, "no table set"))
+	}
+
+	if err := checkUnbounded("DELETE", d.where, d.allRows); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
 }
 
 // ToSQL returns the SQL string representation of the delete operation.
@@ -34,22 +127,21 @@ func (d *DeleteContainer) Where(conditions *Expression) *DeleteContainer {
 // If a table name is present in DeleteContainer, it appends it to the SQL elements,
 // and if a table alias is different from the table name, it appends both the table alias, "AS", and
 func (d *DeleteContainer) ToSQL() (string, error) {
-	if len(d.errs) > 0 {
-		return "", errors.Join(d.errs...)
-	}
-
+	r := &renderer{dialect: d.dialect, encoders: d.encoders, statement: "DELETE", clause: "WHERE", errs: d.validate()}
 	sqlElements := []string{"DELETE FROM"}
 
-	if d.table != nil {
-		if d.table.name != d.table.bName {
-			sqlElements = append(sqlElements, d.table.bName, "AS", d.table.name)
-		} else {
-			sqlElements = append(sqlElements, d.table.name)
-		}
+	if d.table != nil && d.table.name != d.table.bName {
+		sqlElements = append(sqlElements, d.table.bName, "AS", d.table.name)
+	} else if d.table != nil {
+		sqlElements = append(sqlElements, d.table.name)
 	}
 
 	if d.where != nil {
-		sqlElements = append(sqlElements, "WHERE", d.where.condition)
+		sqlElements = append(sqlElements, "WHERE", r.expression(d.where))
+	}
+
+	if len(r.errs) > 0 {
+		return "", errors.Join(r.errs...)
 	}
 
 	return fmt.Sprintf("%s;", strings.Join(sqlElements, " ")), nil
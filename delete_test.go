@@ -12,7 +12,7 @@ type DeleteSuite struct {
 }
 
 func (s *DeleteSuite) Test_Delete() {
-	sb := fsb.Delete(fsb.Table("users"))
+	sb := fsb.Delete(fsb.Table("users")).AllRows()
 	sql, err := sb.ToSQL()
 
 	assert.Equal(s.T(), "DELETE FROM users;", sql)
@@ -27,6 +27,40 @@ func (s *DeleteSuite) Test_DeleteWhere() {
 	assert.Nil(s.T(), err)
 }
 
+func (s *DeleteSuite) Test_DeleteAndWhere() {
+	sb := fsb.Delete(fsb.Table("users")).
+		AndWhere(fsb.Eq("id", 1)).
+		OrWhere(fsb.Eq("id", 2)).
+		WhereIf(false, fsb.Eq("deleted", true))
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "DELETE FROM users WHERE id = 1 OR id = 2;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_DeleteValidate tests that a delete operation without a table is rejected instead of rendering DELETE FROM;.
+func (s *DeleteSuite) Test_DeleteValidate() {
+	sql, err := fsb.Delete(nil).AllRows().ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "DELETE FROM: no table set")
+}
+
+// Test_DeleteUnbounded tests that deleting every row requires AllRows.
+func (s *DeleteSuite) Test_DeleteUnbounded() {
+	sql, err := fsb.Delete(fsb.Table("users")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "DELETE WHERE: missing WHERE clause; use AllRows to change every row")
+
+	sql, err = fsb.Delete(fsb.Table("users")).Where(fsb.Eq(1, 1).AND(fsb.Eq("id", 1))).ToSQL()
+	assert.Equal(s.T(), "DELETE FROM users WHERE 1 = 1 AND id = 1;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Delete(fsb.Table("users")).Where(fsb.Eq(1, 1).OR(fsb.Eq("id", 1))).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "DELETE WHERE: condition is always true; use AllRows to change every row")
+}
+
 func TestDeleteSuite(t *testing.T) {
 	suite.Run(t, new(DeleteSuite))
 }
\ No newline at end of file
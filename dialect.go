@@ -0,0 +1,30 @@
+package fsb
+
+// Dialect
+// Dialect selects the database the SQL is generated for.
+// The zero value, Generic, keeps the syntax fsb has always produced.
+type Dialect int
+
+const (
+	Generic Dialect = iota
+	MySQL
+	PostgreSQL
+	SQLite
+	SQLServer
+)
+
+// String returns the name of the dialect.
+func (d Dialect) String() string {
+	switch d {
+	case MySQL:
+		return "MySQL"
+	case PostgreSQL:
+		return "PostgreSQL"
+	case SQLite:
+		return "SQLite"
+	case SQLServer:
+		return "SQL Server"
+	default:
+		return "Generic"
+	}
+}
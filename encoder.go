@@ -0,0 +1,112 @@
+package fsb
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+)
+
+// EncoderFunc converts a value of a domain type into a value fsb can render,
+// such as a string, an int64, a []byte or a time.Time.
+type EncoderFunc func(value interface{}) (interface{}, error)
+
+// Encoders
+// Encoders is a registry of the encoders that decide how values of domain types are rendered.
+// A statement uses the registry given to its Encoders method, or DefaultEncoders when it has none,
+// and so do its conditions and the subqueries that have no registry of their own.
+// Every value position consults the registry before the built-in types: conditions such as Eq and In,
+// InsertContainer.Value and UpdateContainer.Set.
+// It is safe for concurrent use.
+type Encoders struct {
+	mu sync.RWMutex
+	m  map[reflect.Type]EncoderFunc
+}
+
+// DefaultEncoders is the registry used by statements without their own, by standalone conditions and by ConvertColumn.
+// Packages that share a process should prefer their own registry created with NewEncoders,
+// so that their encoders for the same type do not replace each other.
+var DefaultEncoders = NewEncoders()
+
+// NewEncoders is a function that creates an empty registry of encoders.
+//
+// Example usage:
+//
+//	type UUID [16]byte
+//
+//	encoders := NewEncoders()
+//	encoders.Register(UUID{}, func(v interface{}) (interface{}, error) {
+//		id := v.(UUID)
+//		return hex.EncodeToString(id[:]), nil
+//	})
+//	Select().From(Table("users")).Where(Eq("id", id)).Encoders(encoders)
+func NewEncoders() *Encoders {
+	return &Encoders{m: map[reflect.Type]EncoderFunc{}}
+}
+
+// Register registers how values of the type of sample are rendered.
+// Pointers to the type are dereferenced first, and a nil pointer is still rendered as NULL.
+// Registering a type again replaces its encoder, and registering a nil encoder removes it.
+func (e *Encoders) Register(sample interface{}, encoder EncoderFunc) {
+	typ := reflect.TypeOf(sample)
+	if typ == nil {
+		return
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if encoder == nil {
+		delete(e.m, typ)
+		return
+	}
+
+	e.m[typ] = encoder
+}
+
+// RegisterEncoder is a function that registers an encoder in DefaultEncoders. See Encoders.Register.
+// It is usually called once during program initialization.
+func RegisterEncoder(sample interface{}, encoder EncoderFunc) {
+	DefaultEncoders.Register(sample, encoder)
+}
+
+// lookup returns the encoder registered for the type of the value, or nil.
+// A nil registry has no encoders.
+func (e *Encoders) lookup(v interface{}) EncoderFunc {
+	if e == nil {
+		return nil
+	}
+
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	return e.m[reflect.TypeOf(v)]
+}
+
+// encode converts the value with its registered encoder.
+// ok is false when no encoder is registered for the type of the value.
+func (e *Encoders) encode(v interface{}) (encoded interface{}, ok bool, err error) {
+	encoder := e.lookup(v)
+	if encoder == nil {
+		return v, false, nil
+	}
+
+	encoded, err = encoder(v)
+	if err != nil {
+		return nil, true, fmt.Errorf("encode %T: %w", v, err)
+	}
+
+	if reflect.TypeOf(encoded) == reflect.TypeOf(v) {
+		return nil, true, fmt.Errorf("encoder for %T returned the same type", v)
+	}
+
+	return encoded, true, nil
+}
+
+// registry returns the encoders of the statement being rendered.
+func (r *renderer) registry() *Encoders {
+	if r.encoders != nil {
+		return r.encoders
+	}
+
+	return DefaultEncoders
+}
@@ -0,0 +1,133 @@
+package fsb_test
+
+import (
+	"encoding/hex"
+	"errors"
+	"fsb"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type uuid [16]byte
+
+type money struct {
+	cents int64
+}
+
+type EncoderSuite struct {
+	suite.Suite
+	encoders *fsb.Encoders
+}
+
+func (s *EncoderSuite) SetupTest() {
+	s.encoders = fsb.NewEncoders()
+	s.encoders.Register(uuid{}, func(v interface{}) (interface{}, error) {
+		id := v.(uuid)
+		return hex.EncodeToString(id[:]), nil
+	})
+	s.encoders.Register(money{}, func(v interface{}) (interface{}, error) {
+		m := v.(money)
+		if m.cents < 0 {
+			return nil, errors.New("negative amount")
+		}
+		return m.cents, nil
+	})
+}
+
+// Test_EncoderCondition tests that registered types are encoded in conditions and subqueries.
+func (s *EncoderSuite) Test_EncoderCondition() {
+	id := uuid{0xab}
+	sub := fsb.Select("id").From(fsb.Table("items")).Where(fsb.Eq("price", money{5}))
+	cond := fsb.And(fsb.Eq("id", id), fsb.In("price", money{100}, &money{250}), fsb.In("id", sub))
+
+	sql, err := fsb.Select().From(fsb.Table("items")).Where(cond).Encoders(s.encoders).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM items WHERE id = 'ab000000000000000000000000000000' AND price IN (100, 250) "+
+			"AND id IN (SELECT id FROM items WHERE price = 5);",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_EncoderStatements tests that registered types are encoded in VALUES, SET and WHERE.
+func (s *EncoderSuite) Test_EncoderStatements() {
+	sql, err := fsb.Insert("price").Into(fsb.Table("items")).Value(money{99}).Encoders(s.encoders).ToSQL()
+
+	assert.Equal(s.T(), "INSERT INTO items ( price ) VALUES ( 99 );", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Update(fsb.Table("items")).Set("price", money{150}).Where(fsb.Eq("id", 1)).Encoders(s.encoders).ToSQL()
+
+	assert.Equal(s.T(), "UPDATE items SET price = 150 WHERE id = 1;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Delete(fsb.Table("items")).Where(fsb.Eq("price", money{0})).Encoders(s.encoders).ToSQL()
+
+	assert.Equal(s.T(), "DELETE FROM items WHERE price = 0;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_EncoderRegistries tests that registries do not share encoders and that statements without one use DefaultEncoders.
+func (s *EncoderSuite) Test_EncoderRegistries() {
+	cents := fsb.NewEncoders()
+	cents.Register(money{}, func(v interface{}) (interface{}, error) {
+		return v.(money).cents * 100, nil
+	})
+
+	sql, err := fsb.Select().From(fsb.Table("items")).Where(fsb.Eq("price", money{1})).Encoders(cents).ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM items WHERE price = 100;", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.Select().From(fsb.Table("items")).Where(fsb.Eq("price", money{1})).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidValue))
+}
+
+// Test_EncoderSubquery tests that a subquery uses its own registry and that the registry of the parent is restored after it.
+func (s *EncoderSuite) Test_EncoderSubquery() {
+	sub := fsb.Select("item_id").From(fsb.Table("orders")).Where(fsb.Eq("key", uuid{0xcd})).Encoders(s.encoders)
+
+	sql, err := fsb.Select().From(fsb.Table("items")).Where(fsb.And(fsb.In("id", sub), fsb.Eq("price", money{1}))).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT WHERE: unsupported value type fsb_test.money")
+
+	sql, err = fsb.Select().From(fsb.Table("items")).Where(fsb.In("id", sub)).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM items WHERE id IN (SELECT item_id FROM orders WHERE key = 'cd000000000000000000000000000000');",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_EncoderCountQuery tests that a grouped count query keeps the encoders of the statement.
+func (s *EncoderSuite) Test_EncoderCountQuery() {
+	sb := fsb.Select("status").From(fsb.Table("items")).Where(fsb.Eq("id", uuid{0xab})).GroupBy("status").Encoders(s.encoders)
+
+	sql, err := sb.CountQuery().ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT COUNT(*) FROM (SELECT status FROM items WHERE id = 'ab000000000000000000000000000000' GROUP BY status) AS count_query;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_EncoderError tests that encoder errors are reported.
+func (s *EncoderSuite) Test_EncoderError() {
+	sql, err := fsb.Select().From(fsb.Table("items")).Where(fsb.Eq("price", money{-1})).Encoders(s.encoders).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT WHERE: encode fsb_test.money: negative amount")
+}
+
+func TestEncoderSuite(t *testing.T) {
+	suite.Run(t, new(EncoderSuite))
+}
@@ -0,0 +1,116 @@
+package fsb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrorCode
+// ErrorCode classifies the problems reported by a BuildError.
+type ErrorCode string
+
+const (
+	// CodeMissingClause is used when a required part of a statement, such as the table or the values, is missing.
+	CodeMissingClause ErrorCode = "missing_clause"
+	// CodeInvalidClause is used when a clause is malformed or conflicts with another clause.
+	CodeInvalidClause ErrorCode = "invalid_clause"
+	// CodeUnsupported is used when the dialect of the statement does not support a feature.
+	CodeUnsupported ErrorCode = "unsupported"
+	// CodeInvalidIdentifier is used for aliases, function names and collations that are not plain identifiers.
+	CodeInvalidIdentifier ErrorCode = "invalid_identifier"
+	// CodeInvalidValue is used for values and operands that cannot be rendered.
+	CodeInvalidValue ErrorCode = "invalid_value"
+	// CodeUnbounded is used for an UPDATE or DELETE that would change every row without AllRows.
+	CodeUnbounded ErrorCode = "unbounded"
+	// CodeInvalidCursor is used for cursor tokens that cannot be decoded.
+	CodeInvalidCursor ErrorCode = "invalid_cursor"
+)
+
+var (
+	// ErrMissingClause matches every BuildError with CodeMissingClause.
+	ErrMissingClause = errors.New("missing clause")
+	// ErrInvalidClause matches every BuildError with CodeInvalidClause.
+	ErrInvalidClause = errors.New("invalid clause")
+	// ErrUnsupported matches every BuildError with CodeUnsupported.
+	ErrUnsupported = errors.New("unsupported by dialect")
+	// ErrInvalidIdentifier matches every BuildError with CodeInvalidIdentifier.
+	ErrInvalidIdentifier = errors.New("invalid identifier")
+	// ErrInvalidValue matches every BuildError with CodeInvalidValue.
+	ErrInvalidValue = errors.New("invalid value")
+	// ErrUnbounded matches every BuildError with CodeUnbounded.
+	ErrUnbounded = errors.New("unbounded statement")
+	// ErrInvalidCursor is returned, wrapped, by DecodeCursor and matches every BuildError with CodeInvalidCursor.
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
+
+var codeErrors = map[ErrorCode]error{
+	CodeMissingClause:     ErrMissingClause,
+	CodeInvalidClause:     ErrInvalidClause,
+	CodeUnsupported:       ErrUnsupported,
+	CodeInvalidIdentifier: ErrInvalidIdentifier,
+	CodeInvalidValue:      ErrInvalidValue,
+	CodeUnbounded:         ErrUnbounded,
+	CodeInvalidCursor:     ErrInvalidCursor,
+}
+
+// BuildError
+// BuildError describes one problem found while a statement is built.
+// Statement is the kind of statement, such as SELECT, and Clause the clause the problem was found in, such as ORDER BY.
+// ToSQL and Validate report every problem at once by combining BuildErrors with errors.Join,
+// so callers use errors.As to inspect them and errors.Is with the Err* sentinel of the Code to classify them.
+type BuildError struct {
+	Statement string
+	Clause    string
+	Code      ErrorCode
+	Err       error
+}
+
+func (e *BuildError) Error() string {
+	location := strings.TrimSpace(fmt.Sprintf("%s %s", e.Statement, e.Clause))
+	if location == "" {
+		return e.Err.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", location, e.Err)
+}
+
+// Unwrap returns the sentinel error of the Code and the underlying error.
+func (e *BuildError) Unwrap() []error {
+	if sentinel, ok := codeErrors[e.Code]; ok {
+		return []error{sentinel, e.Err}
+	}
+
+	return []error{e.Err}
+}
+
+// buildError is a function that creates a BuildError with a formatted message.
+// The format may use %w to keep an underlying error.
+func buildError(statement, clause string, code ErrorCode, format string, args ...interface{}) error {
+	return &BuildError{
+		Statement: statement,
+		Clause:    clause,
+		Code:      code,
+		Err:       fmt.Errorf(format, args...),
+	}
+}
+
+// checkUnbounded returns a *BuildError when an UPDATE or DELETE would change every row
+// without the AllRows opt-in: when it has no WHERE clause, or when its condition is always true.
+func checkUnbounded(statement string, where *Expression, allRows bool) error {
+	switch {
+	case allRows:
+		return nil
+	case where == nil:
+		return buildError(statement, "WHERE", CodeUnbounded, "missing WHERE clause; use AllRows to change every row")
+	case where.alwaysTrue():
+		return buildError(statement, "WHERE", CodeUnbounded, "condition is always true; use AllRows to change every row")
+	default:
+		return nil
+	}
+}
+
+// fail records a problem found while rendering the current clause of the statement.
+func (r *renderer) fail(code ErrorCode, format string, args ...interface{}) {
+	r.errs = append(r.errs, buildError(r.statement, r.clause, code, format, args...))
+}
@@ -0,0 +1,125 @@
+package fsb_test
+
+import (
+	"errors"
+	"fsb"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorsSuite struct {
+	suite.Suite
+}
+
+// Test_BuildErrorLocation tests that rendering errors carry the statement, the clause and the code.
+func (s *ErrorsSuite) Test_BuildErrorLocation() {
+	_, err := fsb.Select().From(fsb.Table("users")).OrderA("name").Collate("C;").Dialect(fsb.SQLite).ToSQL()
+
+	var buildErr *fsb.BuildError
+	assert.True(s.T(), errors.As(err, &buildErr))
+	assert.Equal(s.T(), "SELECT", buildErr.Statement)
+	assert.Equal(s.T(), "ORDER BY", buildErr.Clause)
+	assert.Equal(s.T(), fsb.CodeInvalidIdentifier, buildErr.Code)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidIdentifier))
+	assert.False(s.T(), errors.Is(err, fsb.ErrUnsupported))
+}
+
+// Test_BuildErrorMultiple tests that every problem is reported at once.
+func (s *ErrorsSuite) Test_BuildErrorMultiple() {
+	_, err := fsb.Select().
+		From(fsb.Table("users")).
+		Where(fsb.Eq("id", struct{}{})).
+		ForUpdate().
+		Dialect(fsb.SQLite).
+		ToSQL()
+
+	assert.EqualError(
+		s.T(),
+		err,
+		"SELECT WHERE: unsupported value type struct {}\nSELECT FOR: row locking is not supported by SQLite",
+	)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidValue))
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnsupported))
+}
+
+// Test_BuildErrorSubquery tests that errors in a subquery are located in the subquery,
+// and that each problem is reported once.
+func (s *ErrorsSuite) Test_BuildErrorSubquery() {
+	sub := fsb.Select("user_id").From(fsb.Table("orders")).Having(fsb.Gt("COUNT(*)", 1))
+
+	_, err := fsb.Select().From(fsb.Table("users")).Where(fsb.Eq("id", sub)).OrderA(struct{}{}).ToSQL()
+
+	assert.EqualError(
+		s.T(),
+		err,
+		"SELECT HAVING: HAVING requires GROUP BY\n"+
+			"SELECT ORDER BY: unsupported value type struct {}",
+	)
+}
+
+// Test_BuildErrorStructureAndValues tests that structural errors do not hide the errors found while rendering.
+func (s *ErrorsSuite) Test_BuildErrorStructureAndValues() {
+	_, err := fsb.Select().From(fsb.Table("users")).Where(fsb.Eq("id", struct{}{})).Having(fsb.Gt("COUNT(*)", 1)).ToSQL()
+
+	assert.EqualError(
+		s.T(),
+		err,
+		"SELECT HAVING: HAVING requires GROUP BY\nSELECT WHERE: unsupported value type struct {}",
+	)
+
+	_, err = fsb.Insert("id", "name").Into(fsb.Table("users")).Value(struct{}{}).ToSQL()
+
+	assert.EqualError(
+		s.T(),
+		err,
+		"INSERT VALUES: row 1 has 1 values for 2 columns\nINSERT VALUES: unsupported value type struct {}",
+	)
+
+	_, err = fsb.Update(nil).Set("name", struct{}{}).ToSQL()
+
+	assert.EqualError(s.T(), err, "UPDATE TABLE: no table set\n"+
+		"UPDATE WHERE: missing WHERE clause; use AllRows to change every row\n"+
+		"UPDATE SET: unsupported value type struct {}")
+
+	_, err = fsb.Delete(nil).Where(fsb.Eq("id", struct{}{})).ToSQL()
+
+	assert.EqualError(s.T(), err, "DELETE FROM: no table set\nDELETE WHERE: unsupported value type struct {}")
+}
+
+// Test_BuildErrorDistinctOn tests that bad DISTINCT ON and ORDER BY items are each reported once.
+func (s *ErrorsSuite) Test_BuildErrorDistinctOn() {
+	_, err := fsb.Select().From(fsb.Table("logs")).DistinctOn(struct{}{}).OrderA(struct{ a int }{}).ToSQL()
+
+	assert.EqualError(
+		s.T(),
+		err,
+		"SELECT DISTINCT: unsupported value type struct {}\n"+
+			"SELECT ORDER BY: unsupported value type struct { a int }",
+	)
+}
+
+// Test_BuildErrorCursor tests that invalid cursors are classified.
+func (s *ErrorsSuite) Test_BuildErrorCursor() {
+	_, err := fsb.DecodeCursor("!!")
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidCursor))
+
+	_, err = fsb.Select().From(fsb.Table("users")).OrderA("id").After("!!").ToSQL()
+
+	var buildErr *fsb.BuildError
+	assert.True(s.T(), errors.As(err, &buildErr))
+	assert.Equal(s.T(), fsb.CodeInvalidCursor, buildErr.Code)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidCursor))
+}
+
+// Test_BuildErrorUnbounded tests that the unbounded statement guard is classified.
+func (s *ErrorsSuite) Test_BuildErrorUnbounded() {
+	_, err := fsb.Delete(fsb.Table("users")).ToSQL()
+
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnbounded))
+}
+
+func TestErrorsSuite(t *testing.T) {
+	suite.Run(t, new(ErrorsSuite))
+}
@@ -1,513 +1,1110 @@
 package fsb
 
 import (
+	"database/sql/driver"
+	"errors"
 	"fmt"
-	"strconv"
+	"reflect"
 	"strings"
 )
 
+// ExpressionKind
+// ExpressionKind identifies which kind of node an Expression represents in the condition tree.
+type ExpressionKind int
+
+const (
+	// KindComparison is a binary predicate such as `target = value` or `target LIKE value`.
+	KindComparison ExpressionKind = iota + 1
+	// KindPostfix is a unary predicate such as `target IS NULL`.
+	KindPostfix
+	// KindIn is a list predicate such as `target IN (value, value)`.
+	KindIn
+	// KindBetween is a range predicate such as `target BETWEEN start AND end`.
+	KindBetween
+	// KindLogical combines its children with the AND or OR operator.
+	KindLogical
+	// KindNot negates its only child.
+	KindNot
+	// KindGroup encloses its only child in brackets.
+	KindGroup
+)
+
 // Expression
-// AND combines the current expression with another expression using the AND operator.
-// If the other expression contains the OR operator, the current expression will be enclosed in brackets.
+// Expression is a node of a condition tree.
+// Predicate nodes hold a target column and the values it is compared with,
+// while logical nodes hold the child expressions combined with AND or OR.
+// The tree is only rendered to SQL when ToSQL is called,
+// so parentheses are derived from the structure of the tree instead of the text of the condition.
 type Expression struct {
-	condition  string
-	bracketFlg bool
+	kind     ExpressionKind
+	operator string
+	target   interface{}
+	values   []interface{}
+	children []*Expression
 }
 
 // Eq is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// A string target is treated as a column name, while a string comparison value is treated as a literal.
+// The function returns a pointer to an Expression struct representing the "=" comparison.
 func Eq(target, comp interface{}) *Expression {
-	cond := createCondition(target, comp, "=")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, "=")
 }
 
 // Neq is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately with the "!=" operator.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// A string target is treated as a column name, while a string comparison value is treated as a literal.
+// The function returns a pointer to an Expression struct representing the "!=" comparison.
 func Neq(target, comp interface{}) *Expression {
-	cond := createCondition(target, comp, "!=")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, "!=")
 }
 
 // Gt is a function that creates an Expression with a specific condition based on the target and comparison value,
 // where the target is greater than the comparison value.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// A string target is treated as a column name, while a string comparison value is treated as a literal.
+// The function returns a pointer to an Expression struct representing the ">" comparison.
 func Gt(target, comp interface{}) *Expression {
-	cond := createCondition(target, comp, ">")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, ">")
 }
 
 // Gte is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately,
-// with ">=" as the comparison operator.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// A string target is treated as a column name, while a string comparison value is treated as a literal.
+// The function returns a pointer to an Expression struct representing the ">=" comparison.
 func Gte(target, comp interface{}) *Expression {
-	cond := createCondition(target, comp, ">=")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, ">=")
 }
 
 // Lt is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately,
-// with a less than (<) symbol.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// A string target is treated as a column name, while a string comparison value is treated as a literal.
+// The function returns a pointer to an Expression struct representing the "<" comparison.
 func Lt(target, comp interface{}) *Expression {
-	cond := createCondition(target, comp, "<")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, "<")
 }
 
 // Lte is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// A string target is treated as a column name, while a string comparison value is treated as a literal.
+// The function returns a pointer to an Expression struct representing the "<=" comparison.
 func Lte(target, comp interface{}) *Expression {
-	cond := createCondition(target, comp, "<=")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, "<=")
 }
 
 // Like is a function that creates an Expression with a specific condition based
 // on the target and comparison value using the "LIKE" operator.
 // It handles only string comparison values.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// The function returns a pointer to an Expression struct representing the comparison.
 func Like(target, comp string) *Expression {
-	cond := createCondition(target, comp, "LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createCondition(target, comp, "LIKE")
 }
 
 // Nlike is a function that creates an Expression struct with a specific condition based
 // on the target and comparison value.
 // It uses the "NOT LIKE" sign to build the condition.
-// The function returns a pointer to the Expression struct initialized with the condition.
+// The function returns a pointer to the Expression struct representing the comparison.
 func Nlike(target, comp string) *Expression {
-	cond := createCondition(target, comp, "NOT LIKE")
+	return createCondition(target, comp, "NOT LIKE")
+}
 
-	return &Expression{
-		condition: cond,
-	}
+// ILike is a function that creates a case-insensitive LIKE condition.
+// It is rendered as ILIKE on PostgreSQL and emulated with LOWER(target) LIKE LOWER(comp) on the other dialects.
+func ILike(target, comp string) *Expression {
+	return createCondition(target, comp, "ILIKE")
+}
+
+// Nilike is a function that creates the negation of ILike.
+func Nilike(target, comp string) *Expression {
+	return createCondition(target, comp, "NOT ILIKE")
+}
+
+// SimilarTo is a function that creates a SIMILAR TO condition, which matches an SQL regular expression.
+// It is supported by PostgreSQL and the Generic dialect.
+func SimilarTo(target, comp string) *Expression {
+	return createCondition(target, comp, "SIMILAR TO")
+}
+
+// NsimilarTo is a function that creates a NOT SIMILAR TO condition.
+func NsimilarTo(target, comp string) *Expression {
+	return createCondition(target, comp, "NOT SIMILAR TO")
+}
+
+// Regexp is a function that creates a regular expression match.
+// It is rendered as ~ on PostgreSQL and the Generic dialect, and as REGEXP on MySQL and SQLite.
+// SQL Server has no regular expression operator, so it is reported as an error there.
+func Regexp(target, comp string) *Expression {
+	return createCondition(target, comp, "REGEXP")
+}
+
+// Nregexp is a function that creates a negated regular expression match, rendered as !~ or NOT REGEXP.
+func Nregexp(target, comp string) *Expression {
+	return createCondition(target, comp, "NOT REGEXP")
 }
 
 // Pm is a function that creates an Expression with a condition using the "LIKE" operator.
 // It takes a target string and a comparison value as arguments.
-// The comparison value is converted to a SQL like prefix pattern using the sqlLikePrefixPattern function.
-// The createCondition function is used to create the condition string with the target,
-// converted comparison value, and "LIKE" operator.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// The comparison value is a string or a number, and a % wildcard is added after it.
+// Any other value, including nil, is reported as an error when the SQL is generated.
+// The wildcards % and _ and the escape character \ in the value are escaped and an ESCAPE clause is added,
+// so a search for "50%" only matches values starting with "50%".
+// The function returns a pointer to an Expression struct representing the comparison.
 func Pm(target, comp interface{}) *Expression {
-	cond := createCondition(target, sqlLikePrefixPattern(comp), "LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createLike(target, comp, false, true, "LIKE")
 }
 
 // Npm is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It uses the createCondition function to build the condition using the target,
-// the comparison value converted into a SQL like prefix pattern, and the "NOT LIKE" sign.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// It accepts the same values as Pm and builds the same pattern with the "NOT LIKE" sign.
+// The function returns a pointer to an Expression struct representing the comparison.
 func Npm(target, comp interface{}) *Expression {
-	cond := createCondition(target, sqlLikePrefixPattern(comp), "NOT LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createLike(target, comp, false, true, "NOT LIKE")
 }
 
 // Sm is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It uses the createCondition function to build the condition string using the target,
-// a modified comparison value obtained from the sqlLikeSuffixPattern function, and the "LIKE"
+// It accepts the same values as Pm, but the % wildcard is added before the value, so it matches a suffix.
+// Wildcards in the value are escaped in the same way as Pm.
 func Sm(target, comp interface{}) *Expression {
-	cond := createCondition(target, sqlLikeSuffixPattern(comp), "LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createLike(target, comp, true, false, "LIKE")
 }
 
 // Nsm is a function that creates an Expression with a specific "NOT LIKE" condition
 // based on the target and comparison value.
-// It can handle string and int comparison values.
-// The comparison value is converted to a SQL LIKE suffix pattern using the sqlLikeSuffixPattern function.
-// The condition is built using the createCondition function with the target,
-// SQL LIKE suffix pattern, and "NOT LIKE" sign.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// It accepts the same values as Sm and builds the same pattern.
+// The function returns a pointer to an Expression struct representing the comparison.
 func Nsm(target, comp interface{}) *Expression {
-	cond := createCondition(target, sqlLikeSuffixPattern(comp), "NOT LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createLike(target, comp, true, false, "NOT LIKE")
 }
 
 // Psm is a function that creates an Expression with a condition using the LIKE operator.
 // It takes a target string and a comp interface{} as arguments.
-// The function first converts the comp value into a SQL pattern with a prefix
-// and suffix using the sqlLikePrefixPattern and sqlLikeSuffixPattern functions.
-// Then, it calls the createCondition function to create the condition string using the target,
-// the SQL pattern, and the "LIKE" sign.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// It accepts the same values as Pm, and % wildcards are added before and after the value.
+// Wildcards in the value are escaped in the same way as Pm.
+// The function returns a pointer to an Expression struct representing the comparison.
 func Psm(target, comp interface{}) *Expression {
-	cond := createCondition(target, sqlLikePrefixPattern(sqlLikeSuffixPattern(comp)), "LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createLike(target, comp, true, true, "LIKE")
 }
 
 // Npsm is a function that creates an Expression with a specific condition based on the target and comparison value.
-// It can handle string and int comparison values.
-// The comp value is converted to a SQL LIKE pattern with a prefix
-// and suffix using the sqlLikePrefixPattern and sqlLikeSuffixPattern functions.
-// The condition is built using the createCondition function with the target, converted comp value, and "NOT LIKE" sign.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// It accepts the same values as Psm and builds the same pattern.
+// The function returns a pointer to an Expression struct representing the "NOT LIKE" comparison.
 func Npsm(target, comp interface{}) *Expression {
-	cond := createCondition(target, sqlLikePrefixPattern(sqlLikeSuffixPattern(comp)), "NOT LIKE")
-
-	return &Expression{
-		condition: cond,
-	}
+	return createLike(target, comp, true, true, "NOT LIKE")
 }
 
 // Between is a function that creates an Expression
 // with a specific condition based on the target, start, and end values.
-// It can handle string and int start values and expects the end value to have the same type as the start value.
-// The condition is built using the fmt.Sprintf function to format the target and values appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// The start and end values are kept in the tree and rendered when the SQL is generated,
+// so they can be of any value type or a column.
+// The range includes both ends; use InRange for a half-open range.
+// The function returns a pointer to an Expression struct representing the range.
 func Between(target, start, end interface{}) *Expression {
-	var cond string
-	switch v := start.(type) {
-	case string:
-		cond = fmt.Sprintf("%s BETWEEN '%s' TO '%s'", target, v, end.(string))
-	case int:
-		cond = fmt.Sprintf("%s BETWEEN %d TO %d", target, v, end.(int))
+	return &Expression{
+		kind:     KindBetween,
+		operator: "BETWEEN",
+		target:   targetOperand(target),
+		values:   []interface{}{start, end},
 	}
+}
 
-	return &Expression{
-		condition: cond,
+// InRange is a function that creates the half-open range `target >= from AND target < to`,
+// which suits timestamps because consecutive ranges neither overlap nor leave gaps.
+// A nil bound, including a nil pointer, leaves that side of the range open,
+// and nil is returned when both bounds are nil, so the range can be chained directly into Where.
+//
+// Example usage:
+//
+//	InRange("created_at", dayStart, dayStart.AddDate(0, 0, 1))
+//	// created_at >= '2024-01-02 00:00:00Z' AND created_at < '2024-01-03 00:00:00Z'
+func InRange(target, from, to interface{}) *Expression {
+	var conditions []*Expression
+
+	if !isNullValue(from, nil) {
+		conditions = append(conditions, Gte(target, from))
+	}
+
+	if !isNullValue(to, nil) {
+		conditions = append(conditions, Lt(target, to))
 	}
+
+	return And(conditions...)
 }
 
 // Nbetween is a function that creates an Expression
 // with a specific condition based on the target, start, and end values.
-// It can handle string and int comparison values.
-// The condition is built using the fmt.Sprintf function to format the target, start, and end values appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// The start and end values are kept in the tree and rendered when the SQL is generated.
+// The function returns a pointer to an Expression struct representing the negated range.
 func Nbetween(target, start, end interface{}) *Expression {
-	var cond string
-	switch v := start.(type) {
-	case string:
-		cond = fmt.Sprintf("%s NOT BETWEEN '%s' TO '%s'", target, v, end.(string))
-	case int:
-		cond = fmt.Sprintf("%s NOT BETWEEN %d TO %d", target, v, end.(int))
-	}
-
 	return &Expression{
-		condition: cond,
+		kind:     KindBetween,
+		operator: "NOT BETWEEN",
+		target:   targetOperand(target),
+		values:   []interface{}{start, end},
 	}
 }
 
 // In is a function that creates an Expression with a specific condition based on the target and list of values.
 // The target is a string specifying the column name to compare against.
 // The list is a variadic parameter that accepts multiple values to be compared against the target.
-// The values in the list can be of type string, []string, int, or []int.
-// If the values in the list are of type string or []string, they will be enclosed in single quotes in the condition.
-// If the values in the list are of type int or []int, they will be treated as numeric values in the condition.
-// The function iterates over the list and appends the converted values to a results slice.
-// It then uses the fmt.Sprintf function to format the target and the results slice into the condition string.
-// The function returns a pointer to an Expression struct initialized with the condition.
+// Slices of any element type are flattened, except []byte and types with an encoder in the registry of the statement or a Value method,
+// and every value is rendered according to its own type when the SQL is generated.
+// An empty list matches no row and is rendered as 1 = 0, and a single subquery is rendered as target IN (SELECT ...).
+// A []string or []interface{} target is a row value, compared with tuples given as slices.
+// The function returns a pointer to an Expression struct representing the list predicate.
+//
+// Example usage:
+//
+//	In("id", []int64{1, 2}, 3)                                        // id IN (1, 2, 3)
+//	In("id", []int{})                                                 // 1 = 0
+//	In([]string{"a", "b"}, []interface{}{1, 2}, []interface{}{3, 4}) // (a, b) IN ((1, 2), (3, 4))
 func In(target interface{}, list ...interface{}) *Expression {
-	var cond string
-	var results []string
-
-	sFlg := false
+	return createIn(target, "IN", list)
+}
 
-	for _, l := range list {
-		results, sFlg = sqlInPattern(l, results, sFlg)
-	}
+// Nin is a function that creates an Expression with a "NOT IN" condition based on the target and list of values.
+// It accepts the same values as In. An empty list matches every row and is rendered as 1 = 1.
+func Nin(target interface{}, list ...interface{}) *Expression {
+	return createIn(target, "NOT IN", list)
+}
 
-	if sFlg {
-		cond = fmt.Sprintf("%s IN ('%s')", target, strings.Join(results, "', '"))
-	} else {
-		cond = fmt.Sprintf("%s IN (%s)", target, strings.Join(results, ", "))
+// createIn is a function that creates the list predicate of In and Nin.
+// A slice target is converted into a row value whose string elements name columns.
+// The list is kept as it was given and flattened when the SQL is generated, see renderer.listValues.
+func createIn(target interface{}, operator string, list []interface{}) *Expression {
+	if items, ok := listItems(target, nil); ok {
+		row := make(rowValue, len(items))
+		for i, item := range items {
+			row[i] = targetOperand(item)
+		}
+		target = row
 	}
 
 	return &Expression{
-		condition: cond,
+		kind:     KindIn,
+		operator: operator,
+		target:   targetOperand(target),
+		values:   list,
 	}
 }
 
-func Nin(target interface{}, list ...interface{}) *Expression {
-	var cond string
-	var results []string
+// IsNull is a function that creates an Expression with a condition that checks if the target is null.
+// The function returns a pointer to an Expression struct representing "target IS NULL".
+func IsNull(target interface{}) *Expression {
+	return createPostfix(target, "IS NULL")
+}
 
-	sFlg := false
+// IsNotNull is a function that creates an Expression with a condition that checks if the specified target is not null.
+// The function returns a pointer to an Expression struct representing "target IS NOT NULL".
+func IsNotNull(target interface{}) *Expression {
+	return createPostfix(target, "IS NOT NULL")
+}
 
-	for _, l := range list {
-		results, sFlg = sqlInPattern(l, results, sFlg)
+// IsTrue is a function that creates an Expression with a specific condition based on the target being true.
+// The function returns a pointer to an Expression struct representing "target = true".
+func IsTrue(target interface{}) *Expression {
+	return createCondition(target, true, "=")
+}
+
+// IsNotTrue is a function that creates an Expression with a specific condition based on the target string.
+// It checks if the target is not equal to true.
+// The function returns a pointer to an Expression struct representing "target != true".
+func IsNotTrue(target interface{}) *Expression {
+	return createCondition(target, true, "!=")
+}
+
+// IsFalse is a function that creates an Expression with a condition that checks if the target is false.
+// The function returns a pointer to an Expression struct representing "target = false".
+// The created Expression can be combined with other conditions using the AND and OR methods.
+func IsFalse(target interface{}) *Expression {
+	return createCondition(target, false, "=")
+}
+
+// IsNotFalse is a function that creates an Expression with a condition that checks if the target is not false.
+// The function returns a pointer to an Expression struct representing "target != false".
+// The created Expression can be used to build logical expressions using the AND and OR methods.
+func IsNotFalse(target interface{}) *Expression {
+	return createCondition(target, false, "!=")
+}
+
+// EqIfNotZero is a function that creates the same Expression as Eq when the comparison value was provided.
+// It returns nil for a nil value, a nil pointer, an empty slice or the zero value of the type,
+// so that the result can be passed to And, AndWhere or WhereIf and the filter is skipped.
+// A non-nil pointer is dereferenced, so a pointer to a zero value is still compared.
+//
+// Example usage:
+//
+//	var status *string
+//	exp := And(EqIfNotZero("status", status), EqIfNotZero("age", 20))
+//	// Output: age = 20
+func EqIfNotZero(target, comp interface{}) *Expression {
+	return createOptionalCondition(target, comp, "=")
+}
+
+// NeqIfNotZero is a function that creates the same Expression as Neq when the comparison value was provided.
+// Values that were not provided are handled in the same way as EqIfNotZero.
+func NeqIfNotZero(target, comp interface{}) *Expression {
+	return createOptionalCondition(target, comp, "!=")
+}
+
+// GtIfNotZero is a function that creates the same Expression as Gt when the comparison value was provided.
+// Values that were not provided are handled in the same way as EqIfNotZero.
+func GtIfNotZero(target, comp interface{}) *Expression {
+	return createOptionalCondition(target, comp, ">")
+}
+
+// GteIfNotZero is a function that creates the same Expression as Gte when the comparison value was provided.
+// Values that were not provided are handled in the same way as EqIfNotZero.
+func GteIfNotZero(target, comp interface{}) *Expression {
+	return createOptionalCondition(target, comp, ">=")
+}
+
+// LtIfNotZero is a function that creates the same Expression as Lt when the comparison value was provided.
+// Values that were not provided are handled in the same way as EqIfNotZero.
+func LtIfNotZero(target, comp interface{}) *Expression {
+	return createOptionalCondition(target, comp, "<")
+}
+
+// LteIfNotZero is a function that creates the same Expression as Lte when the comparison value was provided.
+// Values that were not provided are handled in the same way as EqIfNotZero.
+func LteIfNotZero(target, comp interface{}) *Expression {
+	return createOptionalCondition(target, comp, "<=")
+}
+
+// PsmIfNotZero is a function that creates the same Expression as Psm when the comparison value was provided.
+// Values that were not provided are handled in the same way as EqIfNotZero.
+func PsmIfNotZero(target, comp interface{}) *Expression {
+	v, ok := optionalValue(comp)
+	if !ok {
+		return nil
 	}
 
-	if sFlg {
-		cond = fmt.Sprintf("%s NOT IN ('%s')", target, strings.Join(results, "', '"))
-	} else {
-		cond = fmt.Sprintf("%s NOT IN (%s)", target, strings.Join(results, ", "))
+	return Psm(target, v)
+}
+
+// InIfNotEmpty is a function that creates the same Expression as In when at least one value was provided.
+// Nil values, nil pointers and empty slices in the list are skipped,
+// and nil is returned when nothing is left, so that the filter can be dropped.
+//
+// Example usage:
+//
+//	var ids []int
+//	exp := And(InIfNotEmpty("id", ids), Eq("deleted", false))
+//	// Output: deleted = false
+func InIfNotEmpty(target interface{}, list ...interface{}) *Expression {
+	var values []interface{}
+	for _, l := range list {
+		if v, ok := optionalList(l); ok {
+			values = append(values, v)
+		}
 	}
 
-	return &Expression{
-		condition: cond,
+	if len(values) == 0 {
+		return nil
 	}
+
+	return In(target, values...)
 }
 
-// IsNull is a function that creates an Expression with a condition that checks if the target is null.
-// The target is formatted using fmt.Sprintf to create the condition "target IS NULL".
-// The function returns a pointer to an Expression struct initialized with the condition.
-func IsNull(target interface{}) *Expression {
-	cond := fmt.Sprintf("%s IS NULL", target)
+// optionalValue reports whether an optional filter value was provided and returns the value to compare with.
+// Nil, nil pointers, empty slices and maps, and zero values are treated as not provided.
+// Non-nil pointers other than columns are dereferenced.
+func optionalValue(v interface{}) (interface{}, bool) {
+	if v == nil {
+		return nil, false
+	}
 
-	return &Expression{
-		condition: cond,
+	if _, ok := v.(*ColumnContainer); ok {
+		return v, true
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil, false
+		}
+		return rv.Elem().Interface(), true
+	case reflect.Slice, reflect.Map:
+		if rv.Len() == 0 {
+			return nil, false
+		}
+		return v, true
+	default:
+		if rv.IsZero() {
+			return nil, false
+		}
+		return v, true
 	}
 }
 
-// IsNotNull is a function that creates an Expression with a condition that checks if the specified target is not null.
-// The condition is built using the fmt.Sprintf function to format the target appropriately.
-// The function returns a pointer to an Expression struct initialized with the condition.
-func IsNotNull(target interface{}) *Expression {
-	cond := fmt.Sprintf("%s IS NOT NULL", target)
+// optionalList reports whether an element passed to InIfNotEmpty holds a value.
+// Unlike optionalValue, zero values such as 0 or "" are kept because they are valid list elements.
+func optionalList(v interface{}) (interface{}, bool) {
+	if v == nil {
+		return nil, false
+	}
 
-	return &Expression{
-		condition: cond,
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil, false
+		}
+		return optionalList(rv.Elem().Interface())
+	case reflect.Slice:
+		if rv.Len() == 0 {
+			return nil, false
+		}
+		return v, true
+	default:
+		return v, true
 	}
 }
 
-// IsTrue is a function that creates an Expression with a specific condition based on the target being true.
-// The condition is built using the fmt.Sprintf function to format the target and the boolean value true.
-// The function returns a pointer to an Expression struct initialized with the condition.
-func IsTrue(target interface{}) *Expression {
-	cond := fmt.Sprintf("%s = true", target)
+// createOptionalCondition is a function that returns a comparison node when the comparison value was provided,
+// and nil otherwise.
+func createOptionalCondition(target, comp interface{}, sign string) *Expression {
+	v, ok := optionalValue(comp)
+	if !ok {
+		return nil
+	}
+
+	return createCondition(target, v, sign)
+}
 
+// createCondition is a function that takes a target, comparison value, and sign string
+// and returns a comparison node for the expression tree.
+func createCondition(target, comp interface{}, sign string) *Expression {
 	return &Expression{
-		condition: cond,
+		kind:     KindComparison,
+		operator: sign,
+		target:   targetOperand(target),
+		values:   []interface{}{comp},
 	}
 }
 
-// IsNotTrue is a function that creates an Expression with a specific condition based on the target string.
-// The condition is built using the fmt.Sprintf function to format the target and comparison value.
-// It checks if the target is not equal to true.
-// The function returns a pointer to an Expression struct initialized with the condition.
-func IsNotTrue(target interface{}) *Expression {
-	cond := fmt.Sprintf("%s != true", target)
-
+// createPostfix is a function that returns a unary node whose operator follows the target, such as "IS NULL".
+func createPostfix(target interface{}, sign string) *Expression {
 	return &Expression{
-		condition: cond,
+		kind:     KindPostfix,
+		operator: sign,
+		target:   targetOperand(target),
 	}
 }
 
-// IsFalse is a function that creates an Expression with a condition that checks if the target is false.
-// The condition is built using the fmt.Sprintf function to format the target as "%s = false".
-// The function returns a pointer to an Expression struct initialized with the condition.
-// Note: The Expression struct is defined as follows:
-//
-//	type Expression struct {
-//	    condition  string
-//	    bracketFlg bool
-//	}
-//
-// The Expression struct has methods like AND and OR that can be used to combine multiple conditions.
-func IsFalse(target interface{}) *Expression {
-	cond := fmt.Sprintf("%s = false", target)
-
+// createLogical is a function that returns a logical node combining the given expressions with the sign operator.
+func createLogical(sign string, exps ...*Expression) *Expression {
 	return &Expression{
-		condition: cond,
+		kind:     KindLogical,
+		operator: sign,
+		children: exps,
 	}
 }
 
-// IsNotFalse is a function that creates an Expression with a condition that checks if the target is not false.
-// It uses the fmt.Sprintf function to format the condition string.
-// The function returns a pointer to an Expression struct initialized with the condition.
-// The created Expression can be used to build logical expressions using the AND and OR methods.
-func IsNotFalse(target interface{}) *Expression {
-	cond := fmt.Sprintf("%s != false", target)
+// alwaysTrue reports whether the condition trivially holds for every row, such as 1 = 1 or id = id,
+// which happens when both sides of an equality render the same SQL, or for NOT IN with an empty list.
+func (e *Expression) alwaysTrue() bool {
+	if e == nil {
+		return true
+	}
 
-	return &Expression{
-		condition: cond,
+	switch e.kind {
+	case KindComparison:
+		switch e.operator {
+		case "=", ">=", "<=", "IS":
+			r := &renderer{}
+			return r.column(e.target) == r.value(e.values[0]) && len(r.errs) == 0
+		}
+	case KindIn:
+		return e.operator == "NOT IN" && len((&renderer{}).listValues(e)) == 0
+	case KindLogical:
+		for _, child := range e.children {
+			if child.alwaysTrue() == (e.operator == "OR") {
+				return e.operator == "OR"
+			}
+		}
+		return e.operator == "AND"
+	case KindGroup:
+		return e.children[0].alwaysTrue()
 	}
+
+	return false
 }
 
-// createCondition is a function that takes a target string, comparison value, and sign string
-// and returns a string representing the condition for the expression.
-// It supports string and int comparison values and formats the condition using fmt.Sprintf.
-// The returned condition string includes the target, sign, and comparison value appropriately formatted.
-func createCondition(target, comp interface{}, sign string) string {
-	tc := ConvertColumn(target, true)
-	cc := ConvertColumn(comp, false)
+// targetOperand converts the target of a predicate into a node of the tree.
+// A string target names a column, so it is stored as a ColumnContainer without a table name.
+// Any other value is stored as it is and rendered as a value.
+func targetOperand(target interface{}) interface{} {
+	if t, ok := target.(string); ok {
+		return &ColumnContainer{col: t}
+	}
 
-	return fmt.Sprintf("%s %s %s", tc, sign, cc)
+	return target
 }
 
 // ConvertColumn is a function that takes a target value and a boolean flag.
 // It converts the target value to a string based on its type, and the flag determines whether to add quotation marks around strings.
+// Values are rendered as SQL literals, and an empty string is returned for values that cannot be rendered.
 func ConvertColumn(target interface{}, nFlg bool) string {
 	switch t := target.(type) {
 	case string:
 		if nFlg {
 			return t
-		} else {
-			return fmt.Sprintf("'%s'", t)
-		}
-	case int:
-		return strconv.Itoa(t)
-	case bool:
-		if t {
-			return "true"
-		} else {
-			return "false"
 		}
+		return quoteString(t, Generic)
 	case *ColumnContainer:
+		if t.tName == "" {
+			return t.col
+		}
 		return fmt.Sprintf("%s.%s", t.tName, t.col)
 	default:
-		return ""
+		valueStr, err := formatValue(target, Generic, DefaultEncoders)
+		if err != nil {
+			return ""
+		}
+		return valueStr
 	}
 }
 
-// toSqlLikePattern is a function that converts a comparison value to a SQL LIKE pattern.
-// It takes an interface{} as an argument and returns a string.
-// If the comparison value is a string, it appends '%' at the end.
-// If the comparison value is an int, it converts it to a string and appends '%' at the end.
-// For any other type, it returns an empty string.
-func sqlLikePrefixPattern(comp interface{}) string {
-	switch v := comp.(type) {
-	case string:
-		return v + "%"
-	case int:
-		return strconv.Itoa(v) + "%"
-	default:
-		return ""
-	}
+// likeEscape is the escape character of the patterns built by Pm, Sm, Psm and their negations.
+const likeEscape = "\\"
+
+// createLike is a function that returns a LIKE node matching comp literally,
+// with a wildcard added before it when leading is set and after it when trailing is set.
+// The pattern is built when the SQL is generated, see renderer.likePattern.
+func createLike(target, comp interface{}, leading, trailing bool, sign string) *Expression {
+	return createCondition(target, likePattern{value: comp, leading: leading, trailing: trailing}, sign)
 }
 
-// sqlLikeSuffixPattern is a function that takes a comparison value as input
-// and returns a string representation of a SQL "LIKE" pattern with a suffix wildcard.
-// It can handle comparison values of type string and int.
-// If the comparison value is a string, the function prepends a "%" character to it.
-// If the comparison value is an int,
-// the function converts it to a string using strconv.Itoa and prepends a "%" character to it.
-// If the comparison value is of any other type, the function returns an empty string.
-// The function is primarily used in conjunction with the createCondition function to generate a SQL
-// WHERE clause condition for a "LIKE" comparison.
-// Example usages of sqlLikeSuffixPattern can be seen in the Sm and Nsm functions.
-func sqlLikeSuffixPattern(comp interface{}) string {
-	switch v := comp.(type) {
-	case string:
-		return "%" + v
-	case int:
-		return "%" + strconv.Itoa(v)
+// likePattern is the value of the LIKE nodes built by Pm, Sm, Psm and their negations.
+type likePattern struct {
+	value    interface{}
+	leading  bool
+	trailing bool
+}
+
+// likePattern renders the pattern of a LIKE node built by createLike.
+// Strings and numbers are accepted, and any other value is reported instead of matching every row.
+// When the text contains characters that are special in a LIKE pattern, they are escaped and escaped is true.
+func (r *renderer) likePattern(p likePattern) (pattern string, escaped bool) {
+	value := p.value
+	if encoded, ok, err := r.registry().encode(value); ok {
+		if err != nil {
+			r.fail(CodeInvalidValue, "%w", err)
+			return "", false
+		}
+		value = encoded
+	}
+
+	var text string
+	switch rv := reflect.ValueOf(value); rv.Kind() {
+	case reflect.String:
+		text = rv.String()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		formatted, err := formatValue(value, r.dialect, r.registry())
+		if err != nil {
+			r.fail(CodeInvalidValue, "%w", err)
+			return "", false
+		}
+		text = formatted
 	default:
-		return ""
+		r.fail(CodeInvalidValue, "unsupported LIKE value %T", p.value)
+		return "", false
+	}
+
+	// [ starts a character class in SQL Server, so it is escaped too.
+	pattern = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`, "[", `\[`).Replace(text)
+	escaped = pattern != text
+
+	if p.leading {
+		pattern = "%" + pattern
 	}
+
+	if p.trailing {
+		pattern += "%"
+	}
+
+	return r.literal(pattern), escaped
 }
 
-func sqlInPattern(l interface{}, results []string, sFlg bool) ([]string, bool) {
-	switch v := l.(type) {
-	case string:
-		results = append(results, v)
-		sFlg = true
-	case []string:
-		results = append(results, v...)
-		sFlg = true
-	case int:
-		results = append(results, strconv.Itoa(v))
-	case []int:
-		for _, i := range v {
-			results = append(results, strconv.Itoa(i))
+// sqlInPattern flattens the list given to In and Nin into the values of the list predicate.
+// Slices are expanded, and any other value is kept as a single element.
+// When the target is a row value of the given width, each slice is a tuple instead,
+// and slices of slices are expanded into their tuples.
+// Slices of a type with an encoder in enc are single values.
+func sqlInPattern(list []interface{}, width int, enc *Encoders) []interface{} {
+	var results []interface{}
+
+	for _, l := range list {
+		items, ok := listItems(l, enc)
+		switch {
+		case !ok:
+			results = append(results, l)
+		case width == 0:
+			results = append(results, items...)
+		case len(items) > 0 && isListValue(items[0], enc):
+			results = append(results, sqlInPattern(items, width, enc)...)
+		default:
+			results = append(results, rowValue(items))
 		}
-	default:
-		println(v)
 	}
 
-	return results, sFlg
+	return results
 }
 
-// AND sets the condition of the Expression object with the logical AND operator.
-// If the exp.condition contains the string "OR", it appends the condition in brackets with the AND operator.
-// Otherwise, it appends the condition with the AND operator without brackets.
+// listItems returns the elements of a slice given to In or Nin.
+// ok is false for values that are rendered as a single literal,
+// such as []byte and types with an encoder in enc or a Value method.
+func listItems(v interface{}, enc *Encoders) (items []interface{}, ok bool) {
+	if !isListValue(v, enc) {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(v)
+	items = make([]interface{}, rv.Len())
+	for i := range items {
+		items[i] = rv.Index(i).Interface()
+	}
+
+	return items, true
+}
+
+// isListValue reports whether the value is a slice that In and Nin expand.
+func isListValue(v interface{}, enc *Encoders) bool {
+	if _, ok := v.(driver.Valuer); ok || enc.lookup(v) != nil {
+		return false
+	}
+
+	rv := reflect.ValueOf(v)
+	return rv.Kind() == reflect.Slice && rv.Type().Elem().Kind() != reflect.Uint8
+}
+
+// AND combines the Expression with another expression using the logical AND operator.
+// It returns a new logical node and leaves both operands untouched.
+// Parentheses are decided when the tree is rendered:
+// an OR node nested inside an AND node is enclosed in brackets, while nested AND nodes are flattened.
 //
 // Example usage:
 //
-//	exp := &Expression{condition: "name = 'test'"}
-//	newExp := exp.AND(&Expression{condition: "id = 1"})
-//	// Output: newExp.condition = "name = 'test' AND id = 1"
+//	exp := Eq("name", "test").AND(Eq("id", 1))
+//	// Output: name = 'test' AND id = 1
 //
-//	exp := &Expression{condition: "name = 'test'"}
-//	newExp := exp.AND(&Expression{condition: "id = 1 OR id = 2"})
-//	// Output: newExp.condition = "name = 'test' AND (id = 1 OR id = 2)"
+//	exp := Eq("name", "test").AND(Eq("id", 1).OR(Eq("id", 2)))
+//	// Output: name = 'test' AND (id = 1 OR id = 2)
 //
-//	exp := &Expression{condition: "name = 'test'"}
-//	newExp := exp.AND(&Expression{condition: "id = 1 AND id = 2"})
-//	// Output: newExp.condition = "name = 'test' AND (id = 1 AND id = 2)"
+//	exp := Eq("name", "test").OR(Eq("id", 1)).AND(Eq("id", 2))
+//	// Output: (name = 'test' OR id = 1) AND id = 2
 func (e *Expression) AND(exp *Expression) *Expression {
-	if strings.Contains(exp.condition, "OR") {
-		e.condition = fmt.Sprintf("%s AND (%s)", e.condition, exp.condition)
-	} else {
-		e.condition = fmt.Sprintf("%s AND %s", e.condition, exp.condition)
-	}
+	return And(e, exp)
+}
+
+// OR combines the Expression with another expression using the logical OR operator.
+// It returns a new logical node and leaves both operands untouched.
+// Parentheses are decided when the tree is rendered:
+// an AND node nested inside an OR node is enclosed in brackets, while nested OR nodes are flattened.
+//
+// Example usage:
+//
+//	exp := Eq("name", "test").OR(Eq("id", 1))
+//	// Output: name = 'test' OR id = 1
+//
+//	exp := Eq("name", "test").OR(Eq("id", 1).AND(Eq("id", 2)))
+//	// Output: name = 'test' OR (id = 1 AND id = 2)
+func (e *Expression) OR(exp *Expression) *Expression {
+	return Or(e, exp)
+}
 
-	return e
+// And is a function that combines all given expressions with the logical AND operator.
+// Nil expressions are ignored, so optional filters can be passed without checking them first.
+// It returns nil when every expression is nil, and the expression itself when only one is left.
+//
+// Example usage:
+//
+//	var nameFilter *Expression
+//	exp := And(Eq("deleted", false), nameFilter, Gt("age", 18))
+//	// Output: deleted = false AND age > 18
+func And(exps ...*Expression) *Expression {
+	return combine("AND", exps)
 }
 
-// OR sets the condition of the Expression object with the logical OR operator.
-// If the exp.condition contains the string "AND", it appends the condition in brackets with the OR operator.
-// Otherwise, it appends the condition with the OR operator without brackets.
+// Or is a function that combines all given expressions with the logical OR operator.
+// Nil expressions are ignored in the same way as And.
 //
-// It also sets the bracketFlg to true, indicating that the condition contains brackets.
+// Example usage:
+//
+//	exp := Or(Eq("id", 1), nil, Eq("id", 2))
+//	// Output: id = 1 OR id = 2
+func Or(exps ...*Expression) *Expression {
+	return combine("OR", exps)
+}
+
+// Not is a function that negates the given expression.
+// The negated expression is always enclosed in brackets, so NOT applies to the whole expression.
+// It returns nil for a nil expression.
 //
 // Example usage:
 //
-//	exp := &Expression{condition: "name = 'test'"}
-//	newExp := exp.OR(&Expression{condition: "id = 1"})
-//	// Output: newExp.condition = "name = 'test' OR id = 1"
+//	exp := Not(Eq("id", 1).OR(Eq("id", 2)))
+//	// Output: NOT (id = 1 OR id = 2)
+func Not(exp *Expression) *Expression {
+	if exp == nil {
+		return nil
+	}
+
+	return &Expression{
+		kind:     KindNot,
+		operator: "NOT",
+		children: []*Expression{exp},
+	}
+}
+
+// Group is a function that encloses the given expression in brackets,
+// even where the precedence of the tree would not require them.
+// It returns nil for a nil expression.
 //
-//	exp := &Expression{condition: "name = 'test'"}
-//	newExp := exp.OR(&Expression{condition: "id = 1 AND id = 2"})
-//	// Output: newExp.condition = "name = 'test' OR (id = 1 AND id = 2)"
+// Example usage:
 //
-//	exp := &Expression{condition: "name = 'test'"}
-//	newExp := exp.OR(&Expression{condition: "id = 1 OR id = 2"})
-//	// Output: newExp.condition = "name = 'test' OR (id = 1 OR id = 2)"
-func (e *Expression) OR(exp *Expression) *Expression {
-	if strings.Contains(exp.condition, "AND") {
-		e.condition = fmt.Sprintf("%s OR (%s)", e.condition, exp.condition)
+//	exp := Eq("name", "test").AND(Group(Eq("id", 1).AND(Eq("age", 2))))
+//	// Output: name = 'test' AND (id = 1 AND age = 2)
+func Group(exp *Expression) *Expression {
+	if exp == nil {
+		return nil
+	}
+
+	return &Expression{
+		kind:     KindGroup,
+		children: []*Expression{exp},
+	}
+}
+
+// combine is a function that drops nil expressions and combines the remaining ones with the sign operator.
+func combine(sign string, exps []*Expression) *Expression {
+	var children []*Expression
+	for _, exp := range exps {
+		if exp != nil {
+			children = append(children, exp)
+		}
+	}
+
+	switch len(children) {
+	case 0:
+		return nil
+	case 1:
+		return children[0]
+	default:
+		return createLogical(sign, children...)
+	}
+}
+
+// Clone returns a deep copy of the expression tree.
+// Expressions are never modified after they are created, so Clone is only needed
+// when a caller wants a tree that shares no memory with the original.
+func (e *Expression) Clone() *Expression {
+	if e == nil {
+		return nil
+	}
+
+	c := *e
+	c.target = cloneOperand(e.target)
+
+	if e.values != nil {
+		c.values = make([]interface{}, len(e.values))
+		for i, v := range e.values {
+			c.values[i] = cloneOperand(v)
+		}
+	}
+
+	if e.children != nil {
+		c.children = make([]*Expression, len(e.children))
+		for i, child := range e.children {
+			c.children[i] = child.Clone()
+		}
+	}
+
+	return &c
+}
+
+// cloneOperand copies operands that are held by pointer; other values are returned as they are.
+func cloneOperand(operand interface{}) interface{} {
+	if col, ok := operand.(*ColumnContainer); ok && col != nil {
+		c := *col
+		return &c
+	}
+
+	return operand
+}
+
+// Kind returns the kind of node the Expression represents.
+func (e *Expression) Kind() ExpressionKind {
+	return e.kind
+}
+
+// Operator returns the operator of the node, such as "=", "IS NULL", "IN", "AND" or "NOT".
+// It returns an empty string for group nodes.
+func (e *Expression) Operator() string {
+	return e.operator
+}
+
+// Target returns the left-hand side of a predicate node.
+// Column names are returned as *ColumnContainer, other values are returned as they were passed.
+// It returns nil for logical nodes.
+func (e *Expression) Target() interface{} {
+	return e.target
+}
+
+// Values returns the right-hand side values of a predicate node.
+// It returns nil for logical and postfix nodes.
+func (e *Expression) Values() []interface{} {
+	if e.values == nil {
+		return nil
+	}
+
+	return append([]interface{}{}, e.values...)
+}
+
+// Children returns the child expressions of a logical, NOT or group node.
+// It returns nil for predicate nodes.
+func (e *Expression) Children() []*Expression {
+	if e.children == nil {
+		return nil
+	}
+
+	return append([]*Expression{}, e.children...)
+}
+
+// ToSQL renders the expression tree as a SQL condition.
+// It returns an error when the tree contains a node that cannot be rendered.
+func (e *Expression) ToSQL() (string, error) {
+	r := &renderer{}
+	sql := r.expression(e)
+
+	if len(r.errs) > 0 {
+		return "", errors.Join(r.errs...)
+	}
+
+	return sql, nil
+}
+
+// renderer
+// renderer turns expression trees into SQL.
+// Statements share one renderer while generating their SQL, so every error found in the tree is collected in errs,
+// dialect decides how constructs that differ between databases are written,
+// and encoders is the registry of the statement, or nil to use DefaultEncoders.
+type renderer struct {
+	dialect   Dialect
+	encoders  *Encoders
+	statement string
+	clause    string
+	errs      []error
+}
+
+// expression renders a single node of the tree and, for logical nodes, its children.
+func (r *renderer) expression(e *Expression) string {
+	if e == nil {
+		r.fail(CodeInvalidValue, "nil expression")
+		return ""
+	}
+
+	switch e.kind {
+	case KindComparison:
+		if isNullValue(e.values[0], r.registry()) {
+			switch e.operator {
+			case "=":
+				return fmt.Sprintf("%s IS NULL", r.column(e.target))
+			case "!=":
+				return fmt.Sprintf("%s IS NOT NULL", r.column(e.target))
+			}
+		}
+		return r.comparison(e)
+	case KindPostfix:
+		return fmt.Sprintf("%s %s", r.column(e.target), e.operator)
+	case KindIn:
+		return r.list(e)
+	case KindBetween:
+		return fmt.Sprintf(
+			"%s %s %s AND %s",
+			r.column(e.target),
+			e.operator,
+			r.value(e.values[0]),
+			r.value(e.values[1]),
+		)
+	case KindLogical:
+		conditions := make([]string, len(e.children))
+		for i, child := range e.children {
+			conditions[i] = r.expression(child)
+			if child != nil && child.kind == KindLogical && child.operator != e.operator {
+				conditions[i] = fmt.Sprintf("(%s)", conditions[i])
+			}
+		}
+
+		return strings.Join(conditions, fmt.Sprintf(" %s ", e.operator))
+	case KindNot:
+		if e.children[0].kind == KindGroup {
+			return fmt.Sprintf("NOT %s", r.expression(e.children[0]))
+		}
+
+		return fmt.Sprintf("NOT (%s)", r.expression(e.children[0]))
+	case KindGroup:
+		return fmt.Sprintf("(%s)", r.expression(e.children[0]))
+	default:
+		r.fail(CodeInvalidValue, "unknown expression kind %d", e.kind)
+		return ""
+	}
+}
+
+// comparison renders a binary predicate.
+// Matching operators whose syntax differs between databases are written for the dialect of the renderer.
+func (r *renderer) comparison(e *Expression) string {
+	if q, ok := e.values[0].(*QuantifiedContainer); ok {
+		return r.quantified(e, q)
+	}
+
+	target := r.column(e.target)
+	operator := e.operator
+	value, escaped := "", false
+	if p, ok := e.values[0].(likePattern); ok {
+		value, escaped = r.likePattern(p)
 	} else {
-		e.condition = fmt.Sprintf("%s OR %s", e.condition, exp.condition)
+		value = r.value(e.values[0])
+	}
+
+	switch e.operator {
+	case "ILIKE", "NOT ILIKE":
+		if r.dialect != PostgreSQL {
+			target = fmt.Sprintf("LOWER(%s)", target)
+			value = fmt.Sprintf("LOWER(%s)", value)
+			operator = strings.TrimSuffix(e.operator, "ILIKE") + "LIKE"
+		}
+	case "SIMILAR TO", "NOT SIMILAR TO":
+		if r.dialect != Generic && r.dialect != PostgreSQL {
+			r.fail(CodeUnsupported, "SIMILAR TO is not supported by %s", r.dialect)
+		}
+	case "REGEXP", "NOT REGEXP":
+		switch r.dialect {
+		case Generic, PostgreSQL:
+			operator = map[string]string{"REGEXP": "~", "NOT REGEXP": "!~"}[e.operator]
+		case SQLServer:
+			r.fail(CodeUnsupported, "regular expressions are not supported by %s", r.dialect)
+		}
+	}
+
+	comparisonStr := fmt.Sprintf("%s %s %s", target, operator, value)
+	if escaped {
+		comparisonStr = fmt.Sprintf("%s ESCAPE %s", comparisonStr, r.literal(likeEscape))
+	}
+
+	return comparisonStr
+}
+
+// list renders a list predicate.
+// SQL does not allow an empty list, so it is replaced with a condition that has the same result,
+// and a single subquery is not enclosed in a second pair of brackets.
+func (r *renderer) list(e *Expression) string {
+	list := r.listValues(e)
+	if len(list) == 0 {
+		if e.operator == "NOT IN" {
+			return "1 = 1"
+		}
+		return "1 = 0"
+	}
+
+	target := r.column(e.target)
+	if sub, ok := list[0].(*SelectContainer); ok && len(list) == 1 {
+		return fmt.Sprintf("%s %s %s", target, e.operator, r.subquery(sub))
+	}
+
+	row, isRow := e.target.(rowValue)
+	values := make([]string, len(list))
+	for i, v := range list {
+		if tuple, ok := v.(rowValue); isRow && (!ok || len(tuple) != len(row)) {
+			width := 1
+			if ok {
+				width = len(tuple)
+			}
+			r.fail(CodeInvalidValue, "tuple needs %d values but got %d", len(row), width)
+		}
+		values[i] = r.value(v)
+	}
+
+	return fmt.Sprintf("%s %s (%s)", target, e.operator, strings.Join(values, ", "))
+}
+
+// listValues flattens the list of a list predicate with the encoders of the renderer.
+func (r *renderer) listValues(e *Expression) []interface{} {
+	width := 0
+	if row, ok := e.target.(rowValue); ok {
+		width = len(row)
 	}
 
-	e.bracketFlg = true
+	return sqlInPattern(e.values, width, r.registry())
+}
+
+// column renders an operand placed on the left-hand side of a predicate.
+func (r *renderer) column(target interface{}) string {
+	switch t := target.(type) {
+	case rowValue:
+		columns := make([]string, len(t))
+		for i, v := range t {
+			columns[i] = r.column(v)
+		}
+		return fmt.Sprintf("(%s)", strings.Join(columns, ", "))
+	case *FuncContainer:
+		return r.function(t)
+	case *CaseContainer:
+		return r.caseExpression(t)
+	case *AliasContainer:
+		return t.alias
+	case *LiteralContainer:
+		return r.value(t.value)
+	case *SelectContainer:
+		return r.subquery(t)
+	case string:
+		return t
+	default:
+		return r.literal(target)
+	}
+}
+
+// value renders an operand placed on the right-hand side of a predicate.
+func (r *renderer) value(comp interface{}) string {
+	switch t := comp.(type) {
+	case rowValue:
+		values := make([]string, len(t))
+		for i, v := range t {
+			values[i] = r.value(v)
+		}
+		return fmt.Sprintf("(%s)", strings.Join(values, ", "))
+	case *FuncContainer:
+		return r.function(t)
+	case *CaseContainer:
+		return r.caseExpression(t)
+	case *AliasContainer:
+		return t.alias
+	case *LiteralContainer:
+		return r.value(t.value)
+	case *SelectContainer:
+		return r.subquery(t)
+	case sqlKeyword:
+		return string(t)
+	default:
+		return r.literal(comp)
+	}
+}
+
+// orderColumn renders an item of ORDER BY, GROUP BY or DISTINCT ON.
+// Besides columns it accepts function calls, CASE expressions and conditions,
+// and reports an error for anything it cannot render instead of dropping it, unless rendering it already did.
+func (r *renderer) orderColumn(column interface{}) string {
+	if e, ok := column.(*Expression); ok {
+		if e.kind == KindLogical {
+			return fmt.Sprintf("(%s)", r.expression(e))
+		}
+		return r.expression(e)
+	}
+
+	errs := len(r.errs)
+	columnStr := r.column(column)
+	if columnStr == "" && len(r.errs) == errs {
+		r.fail(CodeInvalidValue, "unsupported order column %T", column)
+	}
+
+	return columnStr
+}
 
-	return e
-}
\ No newline at end of file
+// rowValue is an operand made of several columns or values, rendered as a row such as (a, b).
+type rowValue []interface{}
@@ -1,12 +1,13 @@
 package fsb_test
 
 import (
+	"database/sql"
+	"errors"
 	"fsb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
-	"reflect"
 	"testing"
-	"unsafe"
+	"time"
 )
 
 type ExpressionSuite struct {
@@ -16,756 +17,1063 @@ type ExpressionSuite struct {
 // Test_EqString is a unit test for the EqString method.
 // It tests the functionality of the EqString method in the ExpressionSuite type.
 // The EqString method creates an Expression object using the Eq function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_EqString() {
 	ex := fsb.Eq("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test = 'user1'", *ps)
+	assert.Equal(s.T(), "test = 'user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_EqInt is a unit test for the EqInt method.
 // It tests the functionality of the EqInt method in the ExpressionSuite type.
 // The EqInt method creates an Expression object using the Eq function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_EqInt() {
 	ex := fsb.Eq("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test = 1", *ps)
+	assert.Equal(s.T(), "test = 1", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NeqString is a unit test for the NeqString method.
 // It tests the functionality of the NeqString method in the ExpressionSuite type.
 // The NeqString method creates an Expression object using the Neq function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NeqString() {
 	ex := fsb.Neq("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test != 'user1'", *ps)
+	assert.Equal(s.T(), "test != 'user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NeqInt is a unit test for the NeqInt method.
 // It tests the functionality of the NeqInt method in the ExpressionSuite type.
 // The NeqInt method creates an Expression object using the Neq function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NeqInt() {
 	ex := fsb.Neq("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test != 1", *ps)
+	assert.Equal(s.T(), "test != 1", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_GtString is a unit test for the GtString method.
 // It tests the functionality of the GtString method in the ExpressionSuite type.
 // The GtString method creates an Expression object using the Gt function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_GtString() {
 	ex := fsb.Gt("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test > 'user1'", *ps)
+	assert.Equal(s.T(), "test > 'user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_GtInt is a unit test for the GtInt method.
 // It tests the functionality of the GtInt method in the ExpressionSuite type.
 // The GtInt method creates an Expression object using the Gt function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_GtInt() {
 	ex := fsb.Gt("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test > 1", *ps)
+	assert.Equal(s.T(), "test > 1", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_GteString is a unit test for the GteString method.
 // It tests the functionality of the GteString method in the ExpressionSuite type.
 // The GteString method creates an Expression object using the Gte function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_GteString() {
 	ex := fsb.Gte("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test >= 'user1'", *ps)
+	assert.Equal(s.T(), "test >= 'user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_GteInt is a unit test for the GteInt method.
 // It tests the functionality of the GteInt method in the ExpressionSuite type.
 // The GteInt method creates an Expression object using the Gte function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_GteInt() {
 	ex := fsb.Gte("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test >= 1", *ps)
+	assert.Equal(s.T(), "test >= 1", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_LtString is a unit test for the LtString method.
 // It tests the functionality of the LtString method in the ExpressionSuite type.
 // The LtString method creates an Expression object using the Lt function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_LtString() {
 	ex := fsb.Lt("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test < 'user1'", *ps)
+	assert.Equal(s.T(), "test < 'user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_LtInt is a unit test for the LtInt method.
 // It tests the functionality of the LtInt method in the ExpressionSuite type.
 // The LtInt method creates an Expression object using the Lt function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_LtInt() {
 	ex := fsb.Lt("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test < 1", *ps)
+	assert.Equal(s.T(), "test < 1", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_LteString is a unit test for the LteString method.
 // It tests the functionality of the LteString method in the ExpressionSuite type.
 // The LteString method creates an Expression object using the Lte function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_LteString() {
 	ex := fsb.Lte("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test <= 'user1'", *ps)
+	assert.Equal(s.T(), "test <= 'user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_LteInt is a unit test for the LteInt method.
 // It tests the functionality of the LteInt method in the ExpressionSuite type.
 // The LteInt method creates an Expression object using the Lte function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_LteInt() {
 	ex := fsb.Lte("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test <= 1", *ps)
+	assert.Equal(s.T(), "test <= 1", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_Like is a unit test for the Like method.
 // It tests the functionality of the Like method in the ExpressionSuite type.
 // The Like method creates an Expression object using the Like function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_Like() {
 	ex := fsb.Like("test", "user1%")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE 'user1%'", *ps)
+	assert.Equal(s.T(), "test LIKE 'user1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_Nlike is a unit test for the Nlike method.
 // It tests the functionality of the Nlike method in the ExpressionSuite type.
 // The Nlike method creates an Expression object using the Nlike function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_Nlike() {
 	ex := fsb.Nlike("test", "user1%")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE 'user1%'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE 'user1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_PmString is a unit test for the PmString method.
 // It tests the functionality of the PmString method in the ExpressionSuite type.
 // The PmString method creates an Expression object using the Pm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_PmString() {
 	ex := fsb.Pm("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE 'user1%'", *ps)
+	assert.Equal(s.T(), "test LIKE 'user1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_PmInt is a unit test for the PmInt method.
 // It tests the functionality of the PmInt method in the ExpressionSuite type.
 // The PmInt method creates an Expression object using the Pm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 // Test_PmInt tests the logic that constructs a LIKE condition with an integer value in the ExpressionSuite.
 // The method creates an Expression object using the Pm function from the fsb package,
 // passing in the target attribute and the integer value.
-// It retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Finally, it asserts that the condition field is equal to the expected value.
+// It renders the Expression object using its ToSQL method.
+// Finally, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_PmInt() {
 	ex := fsb.Pm("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE '1%'", *ps)
+	assert.Equal(s.T(), "test LIKE '1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NpmString is a unit test for the NpmString method.
 // It tests the functionality of the NpmString method in the ExpressionSuite type.
 // The NpmString method creates an Expression object using the Nsm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NpmString() {
 	ex := fsb.Npm("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE 'user1%'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE 'user1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NpmInt is a unit test for the NpmInt method.
 // It tests the functionality of the NpmInt method in the ExpressionSuite type.
 // The NpmInt method creates an Expression object using the Nsm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NpmInt() {
 	ex := fsb.Npm("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE '1%'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE '1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_SmString is a unit test for the SmString method.
 // It tests the functionality of the SmString method in the ExpressionSuite type.
 // The SmString method creates an Expression object using the Sm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_SmString() {
 	ex := fsb.Sm("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE '%user1'", *ps)
+	assert.Equal(s.T(), "test LIKE '%user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_SmInt is a unit test for the SmInt method.
 // It tests the functionality of the SmInt method in the ExpressionSuite type.
 // The SmInt method creates an Expression object using the Sm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_SmInt() {
 	ex := fsb.Sm("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE '%1'", *ps)
+	assert.Equal(s.T(), "test LIKE '%1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NsmString is a unit test for the NsmString method.
 // It tests the functionality of the NsmString method in the ExpressionSuite type.
 // The NsmString method creates an Expression object using the Nsm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NsmString() {
 	ex := fsb.Nsm("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE '%user1'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE '%user1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NsmInt is a unit test for the NsmInt method.
 // It tests the functionality of the NsmInt method in the ExpressionSuite type.
 // The NsmInt method creates an Expression object using the Nsm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 // Example: fsb.Nsm("test", 1) creates an Expression object with the condition "test NOT LIKE '%1'".
-// This unit test checks that the condition field in the created Expression object matches the expected value.
+// This unit test checks that the rendered condition of the created Expression object matches the expected value.
 func (s *ExpressionSuite) Test_NsmInt() {
 	ex := fsb.Nsm("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE '%1"+
-		"'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE '%1'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_PsmString is a unit test for the PsmString method.
 // It tests the functionality of the PsmString method in the ExpressionSuite type.
 // The PsmString method creates an Expression object using the Psm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_PsmString() {
 	ex := fsb.Psm("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE '%user1%'", *ps)
+	assert.Equal(s.T(), "test LIKE '%user1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_PsmInt is a unit test for the PsmInt method.
 // It tests the functionality of the PsmInt method in the ExpressionSuite type.
 // The PsmInt method creates an Expression object using the Psm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 // Example: "test LIKE '%1%'"
 func (s *ExpressionSuite) Test_PsmInt() {
 	ex := fsb.Psm("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test LIKE '%1%'", *ps)
+	assert.Equal(s.T(), "test LIKE '%1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NpsmString is a unit test for the NpsmString method.
 // It tests the functionality of the NpsmString method in the ExpressionSuite type.
 // The NpsmString method creates an Expression object using the Npsm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NpsmString() {
 	ex := fsb.Npsm("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE '%user1%'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE '%user1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NpsmInt is a unit test for the NpsmInt method.
 // It tests the functionality of the NpsmInt method in the ExpressionSuite type.
 // The NpsmInt method creates an Expression object using the Npsm function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NpsmInt() {
 	ex := fsb.Npsm("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT LIKE '%1%'", *ps)
+	assert.Equal(s.T(), "test NOT LIKE '%1%'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_BetweenString is a unit test for the BetweenString method.
 // It tests the functionality of the BetweenString method in the ExpressionSuite type.
 // The BetweenString method creates an Expression object using the Between function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_BetweenString() {
 	ex := fsb.Between("test", "user1", "user2")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test BETWEEN 'user1' TO 'user2'", *ps)
+	assert.Equal(s.T(), "test BETWEEN 'user1' AND 'user2'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_BetweenInt is a unit test for the BetweenInt method.
 // It tests the functionality of the BetweenInt method in the ExpressionSuite type.
 // The BetweenInt method creates an Expression object using the Between function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_BetweenInt() {
 	ex := fsb.Between("test", 1, 5)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test BETWEEN 1 TO 5", *ps)
+	assert.Equal(s.T(), "test BETWEEN 1 AND 5", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NbetweenString is a unit test for the NbetweenString method.
 // It tests the functionality of the NbetweenString method in the ExpressionSuite type.
 // The NbetweenString method creates an Expression object using the Nbetween function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NbetweenString() {
 	ex := fsb.Nbetween("test", "user1", "user2")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT BETWEEN 'user1' TO 'user2'", *ps)
+	assert.Equal(s.T(), "test NOT BETWEEN 'user1' AND 'user2'", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NbetweenInt is a unit test for the NbetweenInt method.
 // It tests the functionality of the NbetweenInt method in the ExpressionSuite type.
 // The NbetweenInt method creates an Expression object using the Nbetween function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NbetweenInt() {
 	ex := fsb.Nbetween("test", 1, 5)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT BETWEEN 1 TO 5", *ps)
+	assert.Equal(s.T(), "test NOT BETWEEN 1 AND 5", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InString is a unit test for the InString method.
 // It tests the functionality of the InString method in the ExpressionSuite type.
 // The InString method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InString() {
 	ex := fsb.In("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN ('user1')", *ps)
+	assert.Equal(s.T(), "test IN ('user1')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InStringMulti is a unit test for the InStringMulti method.
 // It tests the functionality of the InStringMulti method in the ExpressionSuite type.
 // The InStringMulti method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InStringMulti() {
 	ex := fsb.In("test", "user1", "user2")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN ('user1', 'user2')", *ps)
+	assert.Equal(s.T(), "test IN ('user1', 'user2')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InStringSlice is a unit test for the InStringSlice method.
 // It tests the functionality of the InStringSlice method in the ExpressionSuite type.
 // The InStringSlice method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InStringSlice() {
 	ex := fsb.In("test", []string{"user1", "user2"})
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN ('user1', 'user2')", *ps)
+	assert.Equal(s.T(), "test IN ('user1', 'user2')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InStringSliceMulti is a unit test for the InStringSliceMulti method.
 // It tests the functionality of the InStringSliceMulti method in the ExpressionSuite type.
 // The InStringSliceMulti method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InStringSliceMulti() {
 	ex := fsb.In("test", []string{"user1", "user2"}, "user3")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN ('user1', 'user2', 'user3')", *ps)
+	assert.Equal(s.T(), "test IN ('user1', 'user2', 'user3')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InInt is a unit test for the InInt method.
 // It tests the functionality of the InInt method in the ExpressionSuite type.
 // The InInt method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InInt() {
 	ex := fsb.In("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN (1)", *ps)
+	assert.Equal(s.T(), "test IN (1)", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InIntMulti is a unit test for the InIntMulti method.
 // It tests the functionality of the InIntMulti method in the ExpressionSuite type.
 // The InIntMulti method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InIntMulti() {
 	ex := fsb.In("test", 1, 3)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN (1, 3)", *ps)
+	assert.Equal(s.T(), "test IN (1, 3)", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InIntSlice is a unit test for the InIntSlice method.
 // It tests the functionality of the InIntSlice method in the ExpressionSuite type.
 // The InIntSlice method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InIntSlice() {
 	ex := fsb.In("test", []int{1, 5})
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN (1, 5)", *ps)
+	assert.Equal(s.T(), "test IN (1, 5)", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_InIntSliceMutli is a unit test for the InIntSliceMutli method.
 // It tests the functionality of the InIntSliceMutli method in the ExpressionSuite type.
 // The InIntSliceMutli method creates an Expression object using the In function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_InIntSliceMulti() {
 	ex := fsb.In("test", []int{1, 5}, 3)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IN (1, 5, 3)", *ps)
+	assert.Equal(s.T(), "test IN (1, 5, 3)", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinString is a unit test for the NinString method.
 // It tests the functionality of the NinString method in the ExpressionSuite type.
 //
 // The NinString method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinString() {
 	ex := fsb.Nin("test", "user1")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN ('user1')", *ps)
+	assert.Equal(s.T(), "test NOT IN ('user1')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinStringMulti is a unit test for the NinStringMulti method.
 // It tests the functionality of the NinStringMulti method in the ExpressionSuite type.
 // The NinStringMulti method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinStringMulti() {
 	ex := fsb.Nin("test", "user1", "user2")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN ('user1', 'user2')", *ps)
+	assert.Equal(s.T(), "test NOT IN ('user1', 'user2')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinStringSlice is a unit test for the NinStringSlice method.
 // It tests the functionality of the NinStringSlice method in the ExpressionSuite type.
 // The NinStringSlice method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinStringSlice() {
 	ex := fsb.Nin("test", []string{"user1", "user2"})
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN ('user1', 'user2')", *ps)
+	assert.Equal(s.T(), "test NOT IN ('user1', 'user2')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinStringSliceMulti is a unit test for the NinStringSliceMulti method.
 // It tests the functionality of the NinStringSliceMulti method in the ExpressionSuite type.
 // The NinStringSliceMulti method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinStringSliceMulti() {
 	ex := fsb.Nin("test", []string{"user1", "user2"}, "user3")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN ('user1', 'user2', 'user3')", *ps)
+	assert.Equal(s.T(), "test NOT IN ('user1', 'user2', 'user3')", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinInt is a unit test for the NinInt method.
 // It tests the functionality of the NinInt method in the ExpressionSuite type.
 // The NinInt method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinInt() {
 	ex := fsb.Nin("test", 1)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN (1)", *ps)
+	assert.Equal(s.T(), "test NOT IN (1)", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinIntMulti is a unit test for the NinIntMulti method.
 // It tests the functionality of the NinIntMulti method in the ExpressionSuite type.
 // The NinIntMulti method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinIntMulti() {
 	ex := fsb.Nin("test", 1, 3)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN (1, 3)", *ps)
+	assert.Equal(s.T(), "test NOT IN (1, 3)", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_NinIntSlice() {
 	ex := fsb.Nin("test", []int{1, 5})
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN (1, 5)", *ps)
+	assert.Equal(s.T(), "test NOT IN (1, 5)", sql)
+	assert.Nil(s.T(), err)
 }
 
 // Test_NinIntSliceMulti is a unit test for the NinIntSliceMulti method.
 // It tests the functionality of the NinIntSliceMulti method in the ExpressionSuite type.
 // The NinIntSliceMulti method creates an Expression object using the Nin function from the fsb package.
-// It then retrieves the condition field of the Expression object using reflection and unsafe pointer conversion.
-// Lastly, it asserts that the condition field is equal to the expected value.
+// It then renders the Expression object using its ToSQL method.
+// Lastly, it asserts that the rendered condition is equal to the expected value.
 func (s *ExpressionSuite) Test_NinIntSliceMulti() {
 	ex := fsb.Nin("test", []int{1, 5}, 3)
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test NOT IN (1, 5, 3)", *ps)
+	assert.Equal(s.T(), "test NOT IN (1, 5, 3)", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_InMixed tests that slices of any type are flattened and every element is quoted by its own type.
+func (s *ExpressionSuite) Test_InMixed() {
+	sql, err := fsb.In("test", []int64{1, 2}, "a", []interface{}{3.5, true}, []byte("b")).ToSQL()
+
+	assert.Equal(s.T(), "test IN (1, 2, 'a', 3.5, true, X'62')", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.In("test", 1, struct{}{}).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidValue))
+}
+
+// Test_InEmpty tests that empty lists are rendered as constant conditions.
+func (s *ExpressionSuite) Test_InEmpty() {
+	sql, err := fsb.In("test", []string{}).ToSQL()
+
+	assert.Equal(s.T(), "1 = 0", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Nin("test").ToSQL()
+
+	assert.Equal(s.T(), "1 = 1", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.Delete(fsb.Table("users")).Where(fsb.Nin("id", []int{})).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnbounded))
+}
+
+// Test_InRow tests row-value list predicates.
+func (s *ExpressionSuite) Test_InRow() {
+	sql, err := fsb.In([]string{"a", "b"}, []interface{}{1, 2}, []interface{}{3, "x"}).ToSQL()
+
+	assert.Equal(s.T(), "(a, b) IN ((1, 2), (3, 'x'))", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Nin([]string{"a", "b"}, [][]int{{1, 2}, {3, 4}}).ToSQL()
+
+	assert.Equal(s.T(), "(a, b) NOT IN ((1, 2), (3, 4))", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.In([]string{"a", "b"}, []int{1, 2, 3}).ToSQL()
+	assert.EqualError(s.T(), err, "tuple needs 2 values but got 3")
+}
+
+// Test_InSubquery tests that a subquery in a list predicate is enclosed in a single pair of brackets.
+func (s *ExpressionSuite) Test_InSubquery() {
+	sub := fsb.Select("user_id").From(fsb.Table("orders"))
+
+	sql, err := fsb.In("id", sub).ToSQL()
+
+	assert.Equal(s.T(), "id IN (SELECT user_id FROM orders)", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_IsNull() {
 	ex := fsb.IsNull("test")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IS NULL", *ps)
+	assert.Equal(s.T(), "test IS NULL", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_IsNotNull() {
 	ex := fsb.IsNotNull("test")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test IS NOT NULL", *ps)
+	assert.Equal(s.T(), "test IS NOT NULL", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_IsTrue() {
 	ex := fsb.IsTrue("test")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test = true", *ps)
+	assert.Equal(s.T(), "test = true", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_IsNotTrue() {
 	ex := fsb.IsNotTrue("test")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test != true", *ps)
+	assert.Equal(s.T(), "test != true", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_IsFalse() {
 	ex := fsb.IsFalse("test")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
 
-	assert.Equal(s.T(), "test = false", *ps)
+	assert.Equal(s.T(), "test = false", sql)
+	assert.Nil(s.T(), err)
 }
 
 func (s *ExpressionSuite) Test_IsNotFalse() {
 	ex := fsb.IsNotFalse("test")
 
-	v := reflect.ValueOf(ex).Elem()
-	r := v.FieldByName("condition")
-	ps := (*string)(unsafe.Pointer(r.UnsafeAddr()))
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "test != false", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_AndValueContainsOR tests that a value containing "OR" does not add brackets to the AND condition.
+func (s *ExpressionSuite) Test_AndValueContainsOR() {
+	ex := fsb.Eq("order_id", 1).AND(fsb.Eq("fruit", "ORANGE"))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "order_id = 1 AND fruit = 'ORANGE'", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_OrInsideAnd tests that an OR node used as the left operand of AND is enclosed in brackets.
+func (s *ExpressionSuite) Test_OrInsideAnd() {
+	ex := fsb.Eq("id", 1).OR(fsb.Eq("id", 2)).AND(fsb.Eq("name", "test"))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "(id = 1 OR id = 2) AND name = 'test'", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_Inspect tests that the nodes of an expression tree can be inspected.
+func (s *ExpressionSuite) Test_Inspect() {
+	user := fsb.Table("users")
+	ex := fsb.Eq(user.Col("id"), 1).OR(fsb.In("name", "a", "b"))
+
+	assert.Equal(s.T(), fsb.KindLogical, ex.Kind())
+	assert.Equal(s.T(), "OR", ex.Operator())
+	assert.Len(s.T(), ex.Children(), 2)
+
+	eq := ex.Children()[0]
+	assert.Equal(s.T(), fsb.KindComparison, eq.Kind())
+	assert.Equal(s.T(), "=", eq.Operator())
+	assert.Equal(s.T(), user.Col("id"), eq.Target())
+	assert.Equal(s.T(), []interface{}{1}, eq.Values())
+
+	in := ex.Children()[1]
+	assert.Equal(s.T(), fsb.KindIn, in.Kind())
+	assert.Equal(s.T(), "name", in.Target().(*fsb.ColumnContainer).Name())
+	assert.Equal(s.T(), []interface{}{"a", "b"}, in.Values())
+}
+
+// Test_Clone tests that Clone returns a tree that shares no nodes with the original.
+func (s *ExpressionSuite) Test_Clone() {
+	ex := fsb.Eq("id", 1).OR(fsb.Eq("id", 2))
+	c := ex.Clone()
+
+	assert.NotSame(s.T(), ex, c)
+	assert.NotSame(s.T(), ex.Children()[0], c.Children()[0])
+
+	sql, err := c.ToSQL()
+	assert.Equal(s.T(), "id = 1 OR id = 2", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_CombineKeepsOperands tests that AND and OR leave the combined expressions untouched.
+func (s *ExpressionSuite) Test_CombineKeepsOperands() {
+	filter := fsb.Eq("deleted", false)
+	_ = filter.AND(fsb.Eq("id", 1))
+	_ = filter.OR(fsb.Eq("id", 2))
+
+	sql, err := filter.ToSQL()
+	assert.Equal(s.T(), "deleted = false", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_Not tests that Not negates the whole expression.
+func (s *ExpressionSuite) Test_Not() {
+	ex := fsb.Not(fsb.Eq("id", 1).OR(fsb.Eq("id", 2)))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "NOT (id = 1 OR id = 2)", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_NotGroup tests that Not does not add a second pair of brackets around a group.
+func (s *ExpressionSuite) Test_NotGroup() {
+	ex := fsb.Not(fsb.Group(fsb.Eq("id", 1)))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "NOT (id = 1)", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_NotNil tests that Not and Group return nil for a nil expression.
+func (s *ExpressionSuite) Test_NotNil() {
+	assert.Nil(s.T(), fsb.Not(nil))
+	assert.Nil(s.T(), fsb.Group(nil))
+}
+
+// Test_AndVariadic tests that And combines every non-nil expression.
+func (s *ExpressionSuite) Test_AndVariadic() {
+	ex := fsb.And(fsb.Eq("deleted", false), nil, fsb.Gt("age", 18), fsb.Or(fsb.Eq("id", 1), fsb.Eq("id", 2)))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "deleted = false AND age > 18 AND (id = 1 OR id = 2)", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_OrVariadic tests that Or combines every non-nil expression and brackets nested AND expressions.
+func (s *ExpressionSuite) Test_OrVariadic() {
+	ex := fsb.Or(nil, fsb.Eq("id", 1), fsb.And(fsb.Eq("id", 2), fsb.Eq("name", "test")))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "id = 1 OR (id = 2 AND name = 'test')", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_AndSingle tests that And returns the only non-nil expression as it is, and nil when there is none.
+func (s *ExpressionSuite) Test_AndSingle() {
+	eq := fsb.Eq("id", 1)
+
+	assert.Same(s.T(), eq, fsb.And(nil, eq))
+	assert.Nil(s.T(), fsb.And())
+	assert.Nil(s.T(), fsb.Or(nil, nil))
+}
+
+// Test_Group tests that Group forces brackets where precedence does not require them.
+func (s *ExpressionSuite) Test_Group() {
+	ex := fsb.Eq("name", "test").AND(fsb.Group(fsb.Eq("id", 1).AND(fsb.Eq("age", 2))))
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "name = 'test' AND (id = 1 AND age = 2)", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_EqIfNotZero tests that EqIfNotZero skips values that were not provided and dereferences pointers.
+func (s *ExpressionSuite) Test_EqIfNotZero() {
+	var name *string
+	zero := 0
+
+	assert.Nil(s.T(), fsb.EqIfNotZero("name", name))
+	assert.Nil(s.T(), fsb.EqIfNotZero("name", ""))
+	assert.Nil(s.T(), fsb.EqIfNotZero("name", nil))
+
+	sql, err := fsb.EqIfNotZero("age", &zero).ToSQL()
+	assert.Equal(s.T(), "age = 0", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.EqIfNotZero("name", "test").ToSQL()
+	assert.Equal(s.T(), "name = 'test'", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_InIfNotEmpty tests that InIfNotEmpty skips empty lists and keeps zero values inside a list.
+func (s *ExpressionSuite) Test_InIfNotEmpty() {
+	var ids []int
+
+	assert.Nil(s.T(), fsb.InIfNotEmpty("id", ids))
+	assert.Nil(s.T(), fsb.InIfNotEmpty("id"))
+
+	empty := []int{}
+	assert.Nil(s.T(), fsb.InIfNotEmpty("id", &empty))
+	assert.Nil(s.T(), fsb.InIfNotEmpty("id", &ids))
+
+	some := []int{3}
+	sql, err := fsb.InIfNotEmpty("id", &some).ToSQL()
+	assert.Equal(s.T(), "id IN (3)", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.InIfNotEmpty("id", []int{0, 1}).ToSQL()
+	assert.Equal(s.T(), "id IN (0, 1)", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ValueTypes tests that the value types of database/sql are rendered as literals.
+func (s *ExpressionSuite) Test_ValueTypes() {
+	name := "o'neil"
+	cases := []struct {
+		value    interface{}
+		expected string
+	}{
+		{int64(10), "id = 10"},
+		{uint8(7), "id = 7"},
+		{1.5, "id = 1.5"},
+		{float32(0.25), "id = 0.25"},
+		{&name, "id = 'o''neil'"},
+		{[]byte{0xde, 0xad}, "id = X'dead'"},
+		{time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), "id = '2024-01-02 03:04:05Z'"},
+		{sql.NullString{String: "a", Valid: true}, "id = 'a'"},
+		{sql.NullInt64{Int64: 3, Valid: true}, "id = 3"},
+	}
+
+	for _, c := range cases {
+		sql, err := fsb.Eq("id", c.value).ToSQL()
+
+		assert.Equal(s.T(), c.expected, sql)
+		assert.Nil(s.T(), err)
+	}
+}
+
+// Test_EqNull tests that NULL values turn equality into IS NULL and IS NOT NULL.
+func (s *ExpressionSuite) Test_EqNull() {
+	var name *string
+
+	sql, err := fsb.And(fsb.Eq("name", name), fsb.Neq("deleted_at", sql.NullTime{}), fsb.Eq("id", nil)).ToSQL()
+
+	assert.Equal(s.T(), "name IS NULL AND deleted_at IS NOT NULL AND id IS NULL", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_UnsupportedValue tests that values which cannot be rendered are reported instead of dropped.
+func (s *ExpressionSuite) Test_UnsupportedValue() {
+	sql, err := fsb.Eq("id", struct{}{}).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "unsupported value type struct {}")
+}
+
+// Test_BetweenTypes tests ranges of other value types and of columns, and their precedence inside AND.
+func (s *ExpressionSuite) Test_BetweenTypes() {
+	event := fsb.Table("events")
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	sql, err := fsb.And(
+		fsb.Between("created_at", from, from.AddDate(0, 0, 1)),
+		fsb.Nbetween(1.5, event.Col("low"), event.Col("high")),
+	).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"created_at BETWEEN '2024-01-02 00:00:00Z' AND '2024-01-03 00:00:00Z' AND 1.5 NOT BETWEEN events.low AND events.high",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_InRange tests half-open ranges and open bounds.
+func (s *ExpressionSuite) Test_InRange() {
+	var to *time.Time
+	from := time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	sql, err := fsb.InRange("created_at", from, from.AddDate(0, 0, 1)).ToSQL()
+
+	assert.Equal(s.T(), "created_at >= '2024-01-02 00:00:00Z' AND created_at < '2024-01-03 00:00:00Z'", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.InRange("created_at", from, to).ToSQL()
+
+	assert.Equal(s.T(), "created_at >= '2024-01-02 00:00:00Z'", sql)
+	assert.Nil(s.T(), err)
+
+	assert.Nil(s.T(), fsb.InRange("created_at", nil, to))
+}
+
+// Test_PsmEscape tests that wildcards in the value of a pattern match are escaped.
+func (s *ExpressionSuite) Test_PsmEscape() {
+	sql, err := fsb.Psm("name", `50%_off\`).ToSQL()
+
+	assert.Equal(s.T(), `name LIKE '%50\%\_off\\%' ESCAPE '\'`, sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("items")).Where(fsb.Pm("name", "5%")).Dialect(fsb.MySQL).ToSQL()
+
+	assert.Equal(s.T(), `SELECT * FROM items WHERE name LIKE '5\\%%' ESCAPE '\\';`, sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_PmValues tests that numbers are used as patterns and other values are reported instead of matching every row.
+func (s *ExpressionSuite) Test_PmValues() {
+	sql, err := fsb.And(fsb.Psm("name", int64(5)), fsb.Pm("price", 5.5)).ToSQL()
+
+	assert.Equal(s.T(), "name LIKE '%5%' AND price LIKE '5.5%'", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.Pm("name", nil).ToSQL()
+	assert.EqualError(s.T(), err, "unsupported LIKE value <nil>")
+
+	_, err = fsb.Nsm("name", true).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidValue))
+}
+
+// Test_MatchDialects tests that ILIKE, SIMILAR TO and regular expressions are written for each dialect.
+func (s *ExpressionSuite) Test_MatchDialects() {
+	cond := fsb.And(fsb.ILike("name", "a%"), fsb.Nregexp("code", "^[0-9]+$"))
+	cases := map[fsb.Dialect]string{
+		fsb.Generic:    "SELECT * FROM users WHERE LOWER(name) LIKE LOWER('a%') AND code !~ '^[0-9]+$';",
+		fsb.PostgreSQL: "SELECT * FROM users WHERE name ILIKE 'a%' AND code !~ '^[0-9]+$';",
+		fsb.MySQL:      "SELECT * FROM users WHERE LOWER(name) LIKE LOWER('a%') AND code NOT REGEXP '^[0-9]+$';",
+	}
+
+	for dialect, expected := range cases {
+		sql, err := fsb.Select().From(fsb.Table("users")).Where(cond).Dialect(dialect).ToSQL()
+
+		assert.Equal(s.T(), expected, sql)
+		assert.Nil(s.T(), err)
+	}
+
+	sql, err := fsb.Delete(fsb.Table("users")).Where(fsb.SimilarTo("name", "(a|b)%")).Dialect(fsb.PostgreSQL).ToSQL()
+
+	assert.Equal(s.T(), "DELETE FROM users WHERE name SIMILAR TO '(a|b)%';", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_MatchUnsupported tests that matching operators a dialect lacks are reported.
+func (s *ExpressionSuite) Test_MatchUnsupported() {
+	_, err := fsb.Select().From(fsb.Table("users")).Where(fsb.Regexp("name", "^a")).Dialect(fsb.SQLServer).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnsupported))
 
-	assert.Equal(s.T(), "test != false", *ps)
+	_, err = fsb.Select().From(fsb.Table("users")).Where(fsb.SimilarTo("name", "a%")).Dialect(fsb.MySQL).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnsupported))
 }
 
 func TestExpressionSuite(t *testing.T) {
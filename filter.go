@@ -0,0 +1,529 @@
+package fsb
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FilterOperator
+// FilterOperator is the name of an operator that clients may use in a filter, such as "gte" in `age=gte:18`.
+type FilterOperator string
+
+const (
+	OpEq     FilterOperator = "eq"
+	OpNeq    FilterOperator = "neq"
+	OpGt     FilterOperator = "gt"
+	OpGte    FilterOperator = "gte"
+	OpLt     FilterOperator = "lt"
+	OpLte    FilterOperator = "lte"
+	OpIn     FilterOperator = "in"
+	OpNin    FilterOperator = "nin"
+	OpLike   FilterOperator = "like"
+	OpPrefix FilterOperator = "prefix"
+	OpSuffix FilterOperator = "suffix"
+	OpNull   FilterOperator = "null"
+)
+
+// FilterType
+// FilterType is the type a filter value is converted to before it is put into an Expression.
+type FilterType int
+
+const (
+	FilterString FilterType = iota + 1
+	FilterInt
+	FilterFloat
+	FilterBool
+)
+
+var (
+	// ErrUnknownFilter is returned when a client filters on a field that is not allowed.
+	ErrUnknownFilter = errors.New("unknown filter field")
+	// ErrFilterOperator is returned when a client uses an operator that is not allowed for the field.
+	ErrFilterOperator = errors.New("filter operator not allowed")
+	// ErrFilterValue is returned when a filter value cannot be converted to the type of the field.
+	ErrFilterValue = errors.New("invalid filter value")
+	// ErrUnknownSort is returned when a client sorts on a key that is not sortable. See SortKeyError.
+	ErrUnknownSort = errors.New("unknown sort field")
+	// ErrPageValue is returned when the page size, number or offset is invalid.
+	ErrPageValue = errors.New("invalid page value")
+)
+
+// FilterError
+// FilterError describes which parameter of the client input was rejected.
+// Err is one of the Err* sentinel errors above, so callers can use errors.Is to classify the problem.
+type FilterError struct {
+	Param string
+	Value string
+	Err   error
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("%s: %s %q", e.Param, e.Err, e.Value)
+}
+
+func (e *FilterError) Unwrap() error {
+	return e.Err
+}
+
+// FilterSchema
+// FilterSchema is the allowlist of fields, operators and sort keys a client may use for one table.
+// Only columns registered here ever reach the generated SQL; the names sent by the client are used as lookup keys only.
+type FilterSchema struct {
+	table       *TableContainer
+	fields      map[string]*filterField
+	sorts       *SortSpec
+	maxPageSize int
+}
+
+type filterField struct {
+	column    *ColumnContainer
+	valueType FilterType
+	operators []FilterOperator
+}
+
+// FilterQuery
+// FilterQuery is the result of parsing client input with a FilterSchema.
+// It holds the WHERE conditions, the order and the page requested by the client.
+type FilterQuery struct {
+	where  *Expression
+	orders []sortOrder
+	limit  int
+	offset int
+}
+
+// Filter is a function that creates a new FilterSchema for the given table.
+// Nothing is allowed until fields are registered with Allow, AllowColumn and Sortable.
+func Filter(table *TableContainer) *FilterSchema {
+	return &FilterSchema{
+		table:  table,
+		fields: map[string]*filterField{},
+		sorts:  SortKeys(),
+	}
+}
+
+// Allow registers the column of the schema table with the given name as a filter field.
+// Values are converted to valueType, and only the listed operators may be used.
+// When no operator is listed, only OpEq is allowed.
+// OpLike, OpPrefix and OpSuffix only apply to FilterString fields and are rejected for the other types.
+func (f *FilterSchema) Allow(name string, valueType FilterType, operators ...FilterOperator) *FilterSchema {
+	return f.AllowColumn(name, f.table.Col(name), valueType, operators...)
+}
+
+// AllowColumn registers a filter field whose public name differs from the column it filters.
+func (f *FilterSchema) AllowColumn(
+	name string,
+	column *ColumnContainer,
+	valueType FilterType,
+	operators ...FilterOperator,
+) *FilterSchema {
+	c := f.clone()
+
+	if len(operators) == 0 {
+		operators = []FilterOperator{OpEq}
+	}
+
+	c.fields[name] = &filterField{
+		column:    column,
+		valueType: valueType,
+		operators: operators,
+	}
+
+	return c
+}
+
+// Sortable registers columns of the schema table that clients may sort on.
+func (f *FilterSchema) Sortable(names ...string) *FilterSchema {
+	c := f.clone()
+
+	for _, name := range names {
+		c.sorts = c.sorts.Key(name, f.table.Col(name))
+	}
+
+	return c
+}
+
+// SortableColumn registers a sort key whose public name differs from the column it sorts on.
+func (f *FilterSchema) SortableColumn(name string, column *ColumnContainer) *FilterSchema {
+	c := f.clone()
+	c.sorts = c.sorts.Key(name, column)
+
+	return c
+}
+
+// TieBreaker sets the column appended after the requested sort keys to make the order deterministic.
+// See SortSpec.TieBreaker.
+func (f *FilterSchema) TieBreaker(column *ColumnContainer) *FilterSchema {
+	c := f.clone()
+	c.sorts = c.sorts.TieBreaker(column)
+
+	return c
+}
+
+// MaxPageSize sets the largest page size a client may request. Zero means no limit.
+func (f *FilterSchema) MaxPageSize(size int) *FilterSchema {
+	c := f.clone()
+	c.maxPageSize = size
+
+	return c
+}
+
+func (f *FilterSchema) clone() *FilterSchema {
+	c := *f
+
+	c.fields = make(map[string]*filterField, len(f.fields))
+	for name, field := range f.fields {
+		c.fields[name] = field
+	}
+
+	return &c
+}
+
+// ParseQuery converts HTTP query parameters into a FilterQuery.
+// Filters are written as `field=operator:value`, where the operator defaults to eq
+// and the values of in and nin are separated by commas.
+// The reserved parameters are `sort` (comma separated keys, prefixed with "-" for descending order)
+// and `page[size]`, `page[number]` (starting at 1) and `page[offset]`.
+//
+// Example usage:
+//
+//	q, err := schema.ParseQuery(r.URL.Query())
+//	// ?status=in:active,pending&age=gte:18&sort=-created_at&page[size]=20
+//	// WHERE age >= 18 AND status IN ('active', 'pending') ORDER BY created_at DESC LIMIT 20
+func (f *FilterSchema) ParseQuery(values url.Values) (*FilterQuery, error) {
+	q := &FilterQuery{}
+	var errs []error
+	var sorts []string
+	var size, number, offset string
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		for _, value := range values[key] {
+			switch key {
+			case "sort":
+				sorts = append(sorts, value)
+			case "page[size]":
+				size = value
+			case "page[number]":
+				number = value
+			case "page[offset]":
+				offset = value
+			default:
+				op, raw := splitFilterValue(value)
+				var list []string
+				if op == OpIn || op == OpNin {
+					list = strings.Split(raw, ",")
+				} else {
+					list = []string{raw}
+				}
+
+				exp, err := f.condition(key, op, list)
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				q.where = And(q.where, exp)
+			}
+		}
+	}
+
+	errs = append(errs, f.parseSort(q, sorts)...)
+	errs = append(errs, f.parsePage(q, size, number, offset)...)
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return q, nil
+}
+
+// ParseJSON converts a JSON filter document into a FilterQuery.
+// The document accepts the same fields, operators and page parameters as ParseQuery:
+//
+//	{
+//	  "filter": {"status": {"in": ["active", "pending"]}, "age": {"gte": 18}},
+//	  "sort": ["-created_at"],
+//	  "page": {"size": 20, "number": 1}
+//	}
+func (f *FilterSchema) ParseJSON(data []byte) (*FilterQuery, error) {
+	var doc struct {
+		Filter map[string]map[string]interface{} `json:"filter"`
+		Sort   []string                          `json:"sort"`
+		Page   struct {
+			Size   json.Number `json:"size"`
+			Number json.Number `json:"number"`
+			Offset json.Number `json:"offset"`
+		} `json:"page"`
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	q := &FilterQuery{}
+	var errs []error
+
+	names := make([]string, 0, len(doc.Filter))
+	for name := range doc.Filter {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		ops := make([]string, 0, len(doc.Filter[name]))
+		for op := range doc.Filter[name] {
+			ops = append(ops, op)
+		}
+		sort.Strings(ops)
+
+		for _, op := range ops {
+			list, err := jsonFilterValues(doc.Filter[name][op])
+			if err != nil {
+				errs = append(errs, &FilterError{Param: name, Value: op, Err: ErrFilterValue})
+				continue
+			}
+
+			exp, err := f.condition(name, FilterOperator(op), list)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			q.where = And(q.where, exp)
+		}
+	}
+
+	errs = append(errs, f.parseSort(q, doc.Sort)...)
+	errs = append(errs, f.parsePage(q, doc.Page.Size.String(), doc.Page.Number.String(), doc.Page.Offset.String())...)
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	return q, nil
+}
+
+// condition checks a single filter against the schema and converts it into an Expression.
+func (f *FilterSchema) condition(name string, op FilterOperator, raw []string) (*Expression, error) {
+	field, ok := f.fields[name]
+	if !ok {
+		return nil, &FilterError{Param: name, Err: ErrUnknownFilter}
+	}
+
+	if !field.allows(op) {
+		return nil, &FilterError{Param: name, Value: string(op), Err: ErrFilterOperator}
+	}
+
+	if op == OpNull {
+		if len(raw) != 1 {
+			return nil, &FilterError{Param: name, Value: strings.Join(raw, ","), Err: ErrFilterValue}
+		}
+
+		isNull, err := strconv.ParseBool(raw[0])
+		if err != nil {
+			return nil, &FilterError{Param: name, Value: raw[0], Err: ErrFilterValue}
+		}
+		if isNull {
+			return IsNull(field.column), nil
+		}
+		return IsNotNull(field.column), nil
+	}
+
+	values := make([]interface{}, len(raw))
+	for i, r := range raw {
+		v, err := field.convert(r)
+		if err != nil {
+			return nil, &FilterError{Param: name, Value: r, Err: ErrFilterValue}
+		}
+		values[i] = v
+	}
+
+	if op != OpIn && op != OpNin && len(values) != 1 {
+		return nil, &FilterError{Param: name, Value: strings.Join(raw, ","), Err: ErrFilterValue}
+	}
+
+	switch op {
+	case OpEq:
+		return Eq(field.column, values[0]), nil
+	case OpNeq:
+		return Neq(field.column, values[0]), nil
+	case OpGt:
+		return Gt(field.column, values[0]), nil
+	case OpGte:
+		return Gte(field.column, values[0]), nil
+	case OpLt:
+		return Lt(field.column, values[0]), nil
+	case OpLte:
+		return Lte(field.column, values[0]), nil
+	case OpIn:
+		return In(field.column, values...), nil
+	case OpNin:
+		return Nin(field.column, values...), nil
+	case OpLike:
+		return Psm(field.column, values[0]), nil
+	case OpPrefix:
+		return Pm(field.column, values[0]), nil
+	case OpSuffix:
+		return Sm(field.column, values[0]), nil
+	default:
+		return nil, &FilterError{Param: name, Value: string(op), Err: ErrFilterOperator}
+	}
+}
+
+// parseSort resolves the sort keys sent by the client into orders using the SortSpec of the schema.
+func (f *FilterSchema) parseSort(q *FilterQuery, keys []string) []error {
+	orders, err := f.sorts.parse(keys)
+	if err != nil {
+		return []error{err}
+	}
+
+	q.orders = orders
+
+	return nil
+}
+
+// parsePage converts the page parameters into the limit and offset of the query.
+func (f *FilterSchema) parsePage(q *FilterQuery, size, number, offset string) []error {
+	var errs []error
+
+	if size != "" {
+		v, err := strconv.Atoi(size)
+		if err != nil || v < 1 || (f.maxPageSize > 0 && v > f.maxPageSize) {
+			errs = append(errs, &FilterError{Param: "page[size]", Value: size, Err: ErrPageValue})
+		} else {
+			q.limit = v
+		}
+	}
+
+	if number != "" {
+		v, err := strconv.Atoi(number)
+		if err != nil || v < 1 || q.limit == 0 {
+			errs = append(errs, &FilterError{Param: "page[number]", Value: number, Err: ErrPageValue})
+		} else {
+			q.offset = (v - 1) * q.limit
+		}
+	}
+
+	if offset != "" {
+		v, err := strconv.Atoi(offset)
+		if err != nil || v < 0 || number != "" {
+			errs = append(errs, &FilterError{Param: "page[offset]", Value: offset, Err: ErrPageValue})
+		} else {
+			q.offset = v
+		}
+	}
+
+	return errs
+}
+
+func (ff *filterField) allows(op FilterOperator) bool {
+	switch op {
+	case OpLike, OpPrefix, OpSuffix:
+		if ff.valueType != FilterString {
+			return false
+		}
+	}
+
+	for _, o := range ff.operators {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// convert turns a raw filter value into the Go type of the field.
+func (ff *filterField) convert(raw string) (interface{}, error) {
+	switch ff.valueType {
+	case FilterInt:
+		return strconv.Atoi(raw)
+	case FilterFloat:
+		f, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, err
+		}
+		// NaN and infinities parse, but cannot be rendered as SQL literals.
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, ErrFilterValue
+		}
+		return f, nil
+	case FilterBool:
+		return strconv.ParseBool(raw)
+	default:
+		return raw, nil
+	}
+}
+
+// splitFilterValue splits `operator:value` into its operator and value.
+// When the part before the first colon is not an operator, the whole value is compared with eq.
+func splitFilterValue(value string) (FilterOperator, string) {
+	op, raw, found := strings.Cut(value, ":")
+	if !found {
+		return OpEq, value
+	}
+
+	switch FilterOperator(op) {
+	case OpEq, OpNeq, OpGt, OpGte, OpLt, OpLte, OpIn, OpNin, OpLike, OpPrefix, OpSuffix, OpNull:
+		return FilterOperator(op), raw
+	default:
+		return OpEq, value
+	}
+}
+
+// jsonFilterValues converts a decoded JSON scalar or array of scalars into raw filter values.
+func jsonFilterValues(v interface{}) ([]string, error) {
+	switch t := v.(type) {
+	case []interface{}:
+		var list []string
+		for _, e := range t {
+			values, err := jsonFilterValues(e)
+			if err != nil || len(values) != 1 {
+				return nil, ErrFilterValue
+			}
+			list = append(list, values...)
+		}
+		return list, nil
+	case string:
+		return []string{t}, nil
+	case json.Number:
+		return []string{t.String()}, nil
+	case bool:
+		return []string{strconv.FormatBool(t)}, nil
+	default:
+		return nil, ErrFilterValue
+	}
+}
+
+// Where returns the WHERE conditions of the FilterQuery, or nil when the client sent no filter.
+func (q *FilterQuery) Where() *Expression {
+	return q.where
+}
+
+// Apply returns a copy of the SelectContainer with the filters, order and page of the FilterQuery applied.
+// The filters are added to the existing WHERE clause with the AND operator.
+func (q *FilterQuery) Apply(s *SelectContainer) *SelectContainer {
+	c := applySortOrders(s.AndWhere(q.where), q.orders)
+
+	if q.limit > 0 {
+		c = c.Limit(q.limit)
+	}
+
+	if q.offset > 0 {
+		c = c.Offset(q.offset)
+	}
+
+	return c
+}
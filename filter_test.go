@@ -0,0 +1,148 @@
+package fsb_test
+
+import (
+	"errors"
+	"fsb"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FilterSuite struct {
+	suite.Suite
+}
+
+func (s *FilterSuite) schema() *fsb.FilterSchema {
+	return fsb.Filter(fsb.Table("users")).
+		Allow("status", fsb.FilterString, fsb.OpEq, fsb.OpIn).
+		Allow("age", fsb.FilterInt, fsb.OpGte, fsb.OpLte).
+		Allow("name", fsb.FilterString, fsb.OpLike).
+		Allow("deleted_at", fsb.FilterString, fsb.OpNull).
+		Sortable("created_at", "name").
+		MaxPageSize(50)
+}
+
+// Test_ParseQuery tests that query parameters are converted into the WHERE, ORDER BY and LIMIT clauses.
+func (s *FilterSuite) Test_ParseQuery() {
+	values, _ := url.ParseQuery("status=in:active,pending&age=gte:18&sort=-created_at,name&page[size]=20&page[number]=3")
+
+	q, err := s.schema().ParseQuery(values)
+	assert.Nil(s.T(), err)
+
+	sql, err := q.Apply(fsb.Select().From(fsb.Table("users"))).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users WHERE users.age >= 18 AND users.status IN ('active', 'pending') "+
+			"ORDER BY users.created_at DESC, users.name ASC LIMIT 20 OFFSET 40;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ParseQueryDefaultOperator tests that a value without an operator is compared with eq.
+func (s *FilterSuite) Test_ParseQueryDefaultOperator() {
+	values := url.Values{"status": {"active:today"}, "deleted_at": {"null:true"}}
+
+	q, err := s.schema().ParseQuery(values)
+	assert.Nil(s.T(), err)
+
+	sql, err := q.Where().ToSQL()
+
+	assert.Equal(s.T(), "users.deleted_at IS NULL AND users.status = 'active:today'", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ParseQueryRejected tests that fields, operators, values and sort keys outside the allowlist are rejected.
+func (s *FilterSuite) Test_ParseQueryRejected() {
+	values := url.Values{
+		"password":   {"eq:secret"},
+		"status":     {"gt:a"},
+		"age":        {"gte:1; DROP TABLE users"},
+		"sort":       {"password"},
+		"page[size]": {"500"},
+	}
+
+	q, err := s.schema().ParseQuery(values)
+
+	assert.Nil(s.T(), q)
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnknownFilter))
+	assert.True(s.T(), errors.Is(err, fsb.ErrFilterOperator))
+	assert.True(s.T(), errors.Is(err, fsb.ErrFilterValue))
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnknownSort))
+	assert.True(s.T(), errors.Is(err, fsb.ErrPageValue))
+
+	var fe *fsb.FilterError
+	assert.True(s.T(), errors.As(err, &fe))
+}
+
+// Test_ParseQueryLikeType tests that LIKE operators are rejected for fields that are not strings.
+func (s *FilterSuite) Test_ParseQueryLikeType() {
+	schema := fsb.Filter(fsb.Table("users")).
+		Allow("price", fsb.FilterFloat, fsb.OpEq, fsb.OpLike).
+		Allow("active", fsb.FilterBool, fsb.OpEq, fsb.OpPrefix, fsb.OpSuffix)
+	values, _ := url.ParseQuery("price=like:1.5&active=prefix:true")
+
+	q, err := schema.ParseQuery(values)
+
+	assert.Nil(s.T(), q)
+	assert.True(s.T(), errors.Is(err, fsb.ErrFilterOperator))
+
+	var fe *fsb.FilterError
+	assert.True(s.T(), errors.As(err, &fe))
+	assert.Equal(s.T(), "active", fe.Param)
+}
+
+// Test_ParseQueryNonFinite tests that NaN and infinities are rejected as filter values.
+func (s *FilterSuite) Test_ParseQueryNonFinite() {
+	schema := fsb.Filter(fsb.Table("items")).Allow("price", fsb.FilterFloat, fsb.OpGt, fsb.OpIn)
+
+	for _, query := range []string{"price=gt:NaN", "price=gt:Inf", "price=in:1,-Inf"} {
+		values, _ := url.ParseQuery(query)
+
+		q, err := schema.ParseQuery(values)
+
+		assert.Nil(s.T(), q)
+		assert.True(s.T(), errors.Is(err, fsb.ErrFilterValue), query)
+
+		var fe *fsb.FilterError
+		assert.True(s.T(), errors.As(err, &fe), query)
+	}
+}
+
+// Test_ParseJSON tests that a JSON filter document is converted in the same way as query parameters.
+func (s *FilterSuite) Test_ParseJSON() {
+	doc := []byte(`{
+		"filter": {"status": {"in": ["active", "pending"]}, "age": {"gte": 18, "lte": 65}, "name": {"like": "ta"}},
+		"sort": ["-created_at"],
+		"page": {"size": 10, "offset": 5}
+	}`)
+
+	q, err := s.schema().ParseJSON(doc)
+	assert.Nil(s.T(), err)
+
+	sql, err := q.Apply(fsb.Select().From(fsb.Table("users")).Where(fsb.Eq("tenant_id", 1))).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users WHERE tenant_id = 1 AND users.age >= 18 AND users.age <= 65 "+
+			"AND users.name LIKE '%ta%' AND users.status IN ('active', 'pending') "+
+			"ORDER BY users.created_at DESC LIMIT 10 OFFSET 5;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ParseJSONRejected tests that a JSON document with an invalid value type is rejected.
+func (s *FilterSuite) Test_ParseJSONRejected() {
+	q, err := s.schema().ParseJSON([]byte(`{"filter": {"age": {"gte": "old"}, "status": {"eq": {"x": 1}}}}`))
+
+	assert.Nil(s.T(), q)
+	assert.True(s.T(), errors.Is(err, fsb.ErrFilterValue))
+}
+
+func TestFilterSuite(t *testing.T) {
+	suite.Run(t, new(FilterSuite))
+}
@@ -0,0 +1,119 @@
+package fsb
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// FuncContainer
+// This structure represents a SQL function call such as LOWER(users.name).
+// It can be used wherever a column is accepted: in conditions, ORDER BY and the select list.
+type FuncContainer struct {
+	name string
+	args []interface{}
+}
+
+// CaseContainer
+// This structure represents a searched CASE expression:
+// CASE WHEN condition THEN value ... ELSE value END.
+type CaseContainer struct {
+	whens   []caseWhen
+	elseVal interface{}
+	hasElse bool
+}
+
+type caseWhen struct {
+	condition *Expression
+	then      interface{}
+}
+
+// identPattern matches the function and collation names fsb accepts,
+// so that these names cannot be used to inject arbitrary SQL.
+// A name is either bare or enclosed in a pair of double quotes, and never contains -, so -- cannot start a comment.
+var identPattern = regexp.MustCompile(`^(?:[A-Za-z_][A-Za-z0-9_.]*|"[A-Za-z_][A-Za-z0-9_.]*")$`)
+
+// Func is a function that creates a call of the SQL function name with the given arguments.
+// As with the target of a condition, string arguments are column names,
+// *ColumnContainer arguments are table columns, and any other argument is rendered as a value.
+//
+// Example usage:
+//
+//	Func("LOWER", user.Col("name"))   // LOWER(users.name)
+//	Func("COALESCE", "nickname", 0)   // COALESCE(nickname, 0)
+func Func(name string, args ...interface{}) *FuncContainer {
+	return &FuncContainer{
+		name: name,
+		args: args,
+	}
+}
+
+// Case is a function that starts a searched CASE expression. Branches are added with When and Else.
+//
+// Example usage:
+//
+//	Case().When(Eq("status", "vip"), 1).Else(2)
+//	// CASE WHEN status = 'vip' THEN 1 ELSE 2 END
+func Case() *CaseContainer {
+	return &CaseContainer{}
+}
+
+// When returns a copy of the CaseContainer with a WHEN condition THEN value branch added.
+func (c *CaseContainer) When(condition *Expression, then interface{}) *CaseContainer {
+	cc := c.clone()
+	cc.whens = append(cc.whens, caseWhen{condition: condition, then: then})
+
+	return cc
+}
+
+// Else returns a copy of the CaseContainer with the ELSE value set.
+func (c *CaseContainer) Else(value interface{}) *CaseContainer {
+	cc := c.clone()
+	cc.elseVal = value
+	cc.hasElse = true
+
+	return cc
+}
+
+func (c *CaseContainer) clone() *CaseContainer {
+	cc := *c
+	cc.whens = append([]caseWhen(nil), c.whens...)
+
+	return &cc
+}
+
+// function renders a function call.
+func (r *renderer) function(f *FuncContainer) string {
+	if !identPattern.MatchString(f.name) {
+		r.fail(CodeInvalidIdentifier, "invalid function name %q", f.name)
+		return ""
+	}
+
+	args := make([]string, len(f.args))
+	for i, arg := range f.args {
+		args[i] = r.column(arg)
+	}
+
+	return fmt.Sprintf("%s(%s)", f.name, strings.Join(args, ", "))
+}
+
+// caseExpression renders a searched CASE expression.
+func (r *renderer) caseExpression(c *CaseContainer) string {
+	if len(c.whens) == 0 {
+		r.fail(CodeInvalidClause, "CASE without WHEN")
+		return ""
+	}
+
+	elements := []string{"CASE"}
+	for _, when := range c.whens {
+		elements = append(elements, "WHEN", r.expression(when.condition), "THEN", r.value(when.then))
+	}
+
+	if c.hasElse {
+		elements = append(elements, "ELSE", r.value(c.elseVal))
+	}
+
+	elements = append(elements, "END")
+
+	return strings.Join(elements, " ")
+}
@@ -0,0 +1,78 @@
+package fsb_test
+
+import (
+	"errors"
+	"fsb"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type FunctionSuite struct {
+	suite.Suite
+}
+
+// Test_Func tests that a function call can be used as the target of a condition.
+func (s *FunctionSuite) Test_Func() {
+	user := fsb.Table("users")
+	ex := fsb.Eq(fsb.Func("LOWER", user.Col("email")), "a@example.com")
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "LOWER(users.email) = 'a@example.com'", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_FuncInvalidName tests that function names are restricted to identifiers.
+func (s *FunctionSuite) Test_FuncInvalidName() {
+	sql, err := fsb.Eq(fsb.Func("LOWER(x); DROP TABLE users; --", "email"), "a").ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_IdentComment tests that names cannot comment out the rest of the statement.
+func (s *FunctionSuite) Test_IdentComment() {
+	sql, err := fsb.Select().From(fsb.Table("users")).OrderA(fsb.Func("x--", "a")).Limit(3).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidIdentifier))
+
+	sql, err = fsb.Select().From(fsb.Table("users")).OrderA("a").Collate("C--").Limit(3).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidIdentifier))
+
+	sql, err = fsb.Select(fsb.Alias("id", `"x`)).From(fsb.Table("users")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidIdentifier))
+
+	sql, err = fsb.Select(fsb.Alias("id", `"user id"`)).From(fsb.Table("users")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidIdentifier))
+
+	sql, err = fsb.Select().From(fsb.Table("users")).OrderA("a").Collate(`"C"`).ToSQL()
+	assert.Equal(s.T(), `SELECT * FROM users ORDER BY a COLLATE "C" ASC;`, sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_Case tests the rendering of a searched CASE expression.
+func (s *FunctionSuite) Test_Case() {
+	ex := fsb.Gt(fsb.Case().When(fsb.Eq("status", "vip"), 10).When(fsb.IsNull("status"), 0).Else(1), 0)
+
+	sql, err := ex.ToSQL()
+
+	assert.Equal(s.T(), "CASE WHEN status = 'vip' THEN 10 WHEN status IS NULL THEN 0 ELSE 1 END > 0", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_CaseWithoutWhen tests that a CASE expression needs at least one branch.
+func (s *FunctionSuite) Test_CaseWithoutWhen() {
+	sql, err := fsb.Eq(fsb.Case().Else(1), 1).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+func TestFunctionSuite(t *testing.T) {
+	suite.Run(t, new(FunctionSuite))
+}
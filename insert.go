@@ -7,10 +7,12 @@ import (
 )
 
 type InsertContainer struct {
-	fields []string
-	table  *TableContainer
-	values []string
-	errs   []error
+	fields   []string
+	table    *TableContainer
+	values   [][]interface{}
+	dialect  Dialect
+	encoders *Encoders
+	errs     []error
 }
 
 // Insert is a function that returns an instance of InsertContainer, which is used to build SQL insert statements.
@@ -30,55 +32,101 @@ func Insert(fields ...interface{}) *InsertContainer {
 
 	return &InsertContainer{
 		fields: f,
-		values: []string{},
+		values: [][]interface{}{},
 	}
 }
 
 // Into is a method of InsertContainer that sets the table for the SQL insert statement.
 // It takes a *TableContainer as the input parameter and assigns it to the 'table' field of the InsertContainer instance.
-// The method returns the modified InsertContainer instance.
+// The method returns a copy of the InsertContainer instance with the table set.
 func (ic *InsertContainer) Into(table *TableContainer) *InsertContainer {
-	ic.table = table
+	c := ic.Clone()
+	c.table = table
 
-	return ic
+	return c
 }
 
-// Value is a method of InsertContainer that appends a value string to the values slice.
+// Value is a method of InsertContainer that adds a row of values to the SQL insert statement.
 // It takes a variadic number of fields of different types as input parameters.
-// The method converts each field to a string representation based on its type and appends it to the value string.
-// The value string is then appended to the values slice of the InsertContainer instance.
-// The method returns the modified InsertContainer instance.
+// Any type database/sql accepts can be used, including time.Time, []byte, pointers and driver.Valuer;
+// nil and nil pointers are inserted as NULL. The values are rendered when ToSQL is called.
+// The method returns a copy of the InsertContainer instance with the new row added.
 func (ic *InsertContainer) Value(fields ...interface{}) *InsertContainer {
-	values := []string{}
-	for _, field := range fields {
-		switch t := field.(type) {
-		case string:
-			values = append(values, fmt.Sprintf("'%s'", t))
-		case int:
-			values = append(values, fmt.Sprintf("%d", t))
-		case float32, float64:
-			values = append(values, fmt.Sprintf("%f", t))
-		case bool:
-			values = append(values, fmt.Sprintf("%v", t))
-		default:
-			values = append(values, fmt.Sprintf("%v", t))
-		}
+	c := ic.Clone()
+	c.values = append(c.values, append([]interface{}(nil), fields...))
+
+	return c
+}
+
+// Encoders is a method of InsertContainer that sets the registry of encoders used for the values of the statement.
+// Without it, DefaultEncoders is used.
+func (ic *InsertContainer) Encoders(encoders *Encoders) *InsertContainer {
+	c := ic.Clone()
+	c.encoders = encoders
+
+	return c
+}
+
+// Dialect is a method of InsertContainer that sets the database the SQL insert statement is generated for.
+// Values whose literals differ between databases, such as []byte and time.Time, are rendered for this dialect.
+func (ic *InsertContainer) Dialect(d Dialect) *InsertContainer {
+	c := ic.Clone()
+	c.dialect = d
+
+	return c
+}
+
+// Clone returns a deep copy of the InsertContainer.
+// Every builder method works on a clone, so an InsertContainer can be shared and extended safely.
+func (ic *InsertContainer) Clone() *InsertContainer {
+	c := *ic
+
+	c.fields = append([]string(nil), ic.fields...)
+	c.table = ic.table.clone()
+	c.values = append([][]interface{}{}, ic.values...)
+	c.errs = append([]error(nil), ic.errs...)
+
+	return &c
+}
+
+// Validate is a method of InsertContainer that checks the structure of the SQL insert statement without generating it.
+// It returns the errors recorded by the builder methods together with a *BuildError for every invalid clause:
+// a missing table, no rows, and rows whose number of values differs from the column list or from the first row.
+// ToSQL reports the same errors together with the ones found while rendering the values.
+func (ic *InsertContainer) Validate() error {
+	return errors.Join(ic.validate()...)
+}
+
+// validate returns the errors recorded by the builder methods and the structural errors of the statement.
+func (ic *InsertContainer) validate() []error {
+	errs := append([]error(nil), ic.errs...)
+
+	if ic.table == nil || ic.table.name == "" {
+		errs = append(errs, buildError("INSERT", "INTO", CodeMissingClause, "no table set"))
 	}
 
-	ic.values = append(ic.values, strings.Join(values, ", "))
+	if len(ic.values) == 0 {
+		errs = append(errs, buildError("INSERT", "VALUES", CodeMissingClause, "no values provided for insertion"))
+	}
 
-	return ic
+	for i, row := range ic.values {
+		switch {
+		case len(ic.fields) > 0 && len(row) != len(ic.fields):
+			errs = append(errs, buildError("INSERT", "VALUES", CodeInvalidValue, "row %d has %d values for %d columns", i+1, len(row), len(ic.fields)))
+		case len(ic.fields) == 0 && len(row) != len(ic.values[0]):
+			errs = append(errs, buildError("INSERT", "VALUES", CodeInvalidValue, "row %d has %d values but row 1 has %d", i+1, len(row), len(ic.values[0])))
+		}
+	}
+
+	return errs
 }
 
 // ToSQL is a method of InsertContainer that generates a SQL insert statement.
 // It returns a string representation of the generated SQL statement and an error, if any.
-// Before generating the SQL statement, it checks if there are any errors present in the InsertContainer instance.
-// If errors are found, it returns an empty string and joins the errors using the errors.Join function.
+// The errors of Validate and the values that cannot be rendered are reported together,
+// in which case it returns an empty string and joins the errors using the errors.Join function.
 func (ic *InsertContainer) ToSQL() (string, error) {
-	if len(ic.errs) > 0 {
-		return "", errors.Join(ic.errs...)
-	}
-
+	r := &renderer{dialect: ic.dialect, encoders: ic.encoders, statement: "INSERT", clause: "VALUES", errs: ic.validate()}
 	sqlElements := []string{"INSERT"}
 
 	if ic.table != nil {
@@ -95,14 +143,20 @@ func (ic *InsertContainer) ToSQL() (string, error) {
 
 	if len(ic.values) > 0 {
 		sqlElements = append(sqlElements, "VALUES")
-		for i, value := range ic.values {
+		for i, row := range ic.values {
 			if i > 0 {
 				sqlElements = append(sqlElements, ",")
 			}
-			sqlElements = append(sqlElements, "(", value, ")")
+			values := make([]string, len(row))
+			for k, value := range row {
+				values[k] = r.value(value)
+			}
+			sqlElements = append(sqlElements, "(", strings.Join(values, ", "), ")")
 		}
-	} else {
-		ic.errs = append(ic.errs, errors.New("no values provided for insertion"))
+	}
+
+	if len(r.errs) > 0 {
+		return "", errors.Join(r.errs...)
 	}
 
 	return fmt.Sprintf("%s;", strings.Join(sqlElements, " ")), nil
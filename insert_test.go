@@ -1,6 +1,7 @@
 package fsb_test
 
 import (
+	"errors"
 	"fsb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -48,6 +49,49 @@ func (s *InsertSuite) Test_InsertMultiValue() {
 	assert.Nil(s.T(), err)
 }
 
+// Test_InsertShared checks that adding a row to a shared InsertContainer does not change the original.
+func (s *InsertSuite) Test_InsertShared() {
+	base := fsb.Insert("id").Into(fsb.Table("users"))
+	_ = base.Value(1)
+
+	sql, err := base.ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "INSERT VALUES: no values provided for insertion")
+}
+
+// Test_InsertValueTypes tests that rows accept the value types of database/sql, with nil inserted as NULL.
+func (s *InsertSuite) Test_InsertValueTypes() {
+	var nickname *string
+	sb := fsb.Insert("id", "score", "nickname", "avatar").
+		Into(fsb.Table("users")).
+		Value(int64(1), 9.5, nickname, []byte("hi"))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "INSERT INTO users ( id, score, nickname, avatar ) VALUES ( 1, 9.5, NULL, X'6869' );", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Insert("id").Into(fsb.Table("users")).Value(make(chan int)).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "INSERT VALUES: unsupported value type chan int")
+}
+
+// Test_InsertValidate checks that rows whose number of values differs from the column list are rejected.
+func (s *InsertSuite) Test_InsertValidate() {
+	sql, err := fsb.Insert("id", "name").Into(fsb.Table("users")).Value(1, "a").Value(2).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "INSERT VALUES: row 2 has 1 values for 2 columns")
+
+	err = fsb.Insert("id").Value(1).Validate()
+
+	var buildErr *fsb.BuildError
+	assert.True(s.T(), errors.As(err, &buildErr))
+	assert.Equal(s.T(), "INTO", buildErr.Clause)
+}
+
 func TestInsertSuite(t *testing.T) {
 	suite.Run(t, new(InsertSuite))
 }
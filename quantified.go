@@ -0,0 +1,128 @@
+package fsb
+
+import (
+	"fmt"
+	"strings"
+)
+
+// QuantifiedContainer
+// This structure represents the right-hand side of a quantified comparison, such as ANY (SELECT ...) or ALL (ARRAY[1, 2]).
+// It is used as the value of Eq, Neq, Gt, Gte, Lt and Lte.
+type QuantifiedContainer struct {
+	quantifier string
+	list       interface{}
+}
+
+// Any is a function that creates the argument of a comparison that holds when it holds for any element of the list.
+// The list is a slice of any element type or a *SelectContainer.
+// On PostgreSQL a slice is written as an array, while the other dialects expand it into IN or a group of comparisons.
+//
+// Example usage:
+//
+//	Eq("id", Any([]int{1, 2}))                                   // PostgreSQL: id = ANY (ARRAY[1, 2]), others: id IN (1, 2)
+//	Gt("price", Any(Select("price").From(Table("competitors")))) // price > ANY (SELECT price FROM competitors)
+func Any(list interface{}) *QuantifiedContainer {
+	return createQuantified("ANY", list)
+}
+
+// Some is a function that creates the argument of a comparison with the SOME quantifier, which is the same as Any.
+func Some(list interface{}) *QuantifiedContainer {
+	return createQuantified("SOME", list)
+}
+
+// All is a function that creates the argument of a comparison that holds when it holds for every element of the list.
+// It accepts the same lists as Any.
+//
+// Example usage:
+//
+//	Neq("status", All([]string{"banned", "deleted"})) // PostgreSQL: status != ALL (ARRAY['banned', 'deleted']), others: status NOT IN ('banned', 'deleted')
+//	Gt("price", All(Select("price").From(Table("competitors")))) // price > ALL (SELECT price FROM competitors)
+func All(list interface{}) *QuantifiedContainer {
+	return createQuantified("ALL", list)
+}
+
+// createQuantified is a function that creates a QuantifiedContainer from a slice or a subquery.
+// The list is checked when the SQL is generated, so that the encoders of the statement decide what a slice is.
+func createQuantified(quantifier string, list interface{}) *QuantifiedContainer {
+	return &QuantifiedContainer{
+		quantifier: quantifier,
+		list:       list,
+	}
+}
+
+// quantified renders a comparison whose value is a QuantifiedContainer.
+// When the dialect cannot write the quantifier, = ANY and != ALL are written as IN and NOT IN,
+// and comparisons with the elements of a slice are combined with OR for ANY and with AND for ALL.
+func (r *renderer) quantified(e *Expression, q *QuantifiedContainer) string {
+	switch e.operator {
+	case "=", "!=", "<>", ">", ">=", "<", "<=":
+	default:
+		r.fail(CodeInvalidValue, "%s cannot be used with %s", q.quantifier, e.operator)
+		return ""
+	}
+
+	sub, isSub := q.list.(*SelectContainer)
+	values, isList := listItems(q.list, r.registry())
+	if !isSub && !isList {
+		r.fail(CodeInvalidValue, "%s needs a slice or a subquery but got %T", q.quantifier, q.list)
+		return ""
+	}
+
+	target := r.column(e.target)
+	isAll := q.quantifier == "ALL"
+	listOperator := ""
+	switch {
+	case e.operator == "=" && !isAll:
+		listOperator = "IN"
+	case (e.operator == "!=" || e.operator == "<>") && isAll:
+		listOperator = "NOT IN"
+	}
+
+	if isSub {
+		if r.dialect != SQLite {
+			return fmt.Sprintf("%s %s %s %s", target, e.operator, q.quantifier, r.subquery(sub))
+		}
+		if listOperator == "" {
+			r.fail(CodeUnsupported, "%s %s is not supported by %s", e.operator, q.quantifier, r.dialect)
+			return ""
+		}
+		return fmt.Sprintf("%s %s %s", target, listOperator, r.subquery(sub))
+	}
+
+	if r.dialect == PostgreSQL {
+		if len(values) == 0 {
+			return fmt.Sprintf("%s %s %s ('{}')", target, e.operator, q.quantifier)
+		}
+		elements := make([]string, len(values))
+		for i, v := range values {
+			elements[i] = r.value(v)
+		}
+		return fmt.Sprintf("%s %s %s (ARRAY[%s])", target, e.operator, q.quantifier, strings.Join(elements, ", "))
+	}
+
+	if listOperator != "" {
+		return r.list(&Expression{kind: KindIn, operator: listOperator, target: e.target, values: values})
+	}
+
+	switch len(values) {
+	case 0:
+		if isAll {
+			return "1 = 1"
+		}
+		return "1 = 0"
+	case 1:
+		return fmt.Sprintf("%s %s %s", target, e.operator, r.value(values[0]))
+	}
+
+	comparisons := make([]string, len(values))
+	for i, v := range values {
+		comparisons[i] = fmt.Sprintf("%s %s %s", target, e.operator, r.value(v))
+	}
+
+	separator := " OR "
+	if isAll {
+		separator = " AND "
+	}
+
+	return fmt.Sprintf("(%s)", strings.Join(comparisons, separator))
+}
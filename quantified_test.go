@@ -0,0 +1,76 @@
+package fsb_test
+
+import (
+	"errors"
+	"fsb"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type QuantifiedSuite struct {
+	suite.Suite
+}
+
+// Test_Slice tests that a slice is written as an array on PostgreSQL and expanded on the other dialects.
+func (s *QuantifiedSuite) Test_Slice() {
+	cond := fsb.And(fsb.Eq("id", fsb.Any([]int{1, 2})), fsb.Neq("status", fsb.All([]string{"banned", "deleted"})), fsb.Gt("score", fsb.Some([]int{10, 20})))
+	cases := map[fsb.Dialect]string{
+		fsb.PostgreSQL: "SELECT * FROM users WHERE id = ANY (ARRAY[1, 2]) AND status != ALL (ARRAY['banned', 'deleted']) AND score > SOME (ARRAY[10, 20]);",
+		fsb.MySQL:      "SELECT * FROM users WHERE id IN (1, 2) AND status NOT IN ('banned', 'deleted') AND (score > 10 OR score > 20);",
+	}
+
+	for dialect, expected := range cases {
+		sql, err := fsb.Select().From(fsb.Table("users")).Where(cond).Dialect(dialect).ToSQL()
+
+		assert.Equal(s.T(), expected, sql)
+		assert.Nil(s.T(), err)
+	}
+
+	sql, err := fsb.Lt("score", fsb.All([]int{10, 20})).ToSQL()
+
+	assert.Equal(s.T(), "(score < 10 AND score < 20)", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Eq("id", fsb.Any([]int{})).ToSQL()
+
+	assert.Equal(s.T(), "1 = 0", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_Subquery tests quantified comparisons with a subquery.
+func (s *QuantifiedSuite) Test_Subquery() {
+	sub := fsb.Select("price").From(fsb.Table("competitors"))
+
+	sql, err := fsb.Gt("price", fsb.All(sub)).ToSQL()
+
+	assert.Equal(s.T(), "price > ALL (SELECT price FROM competitors)", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("items")).Where(fsb.Eq("price", fsb.Any(sub))).Dialect(fsb.SQLite).ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM items WHERE price IN (SELECT price FROM competitors);", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = fsb.Select().From(fsb.Table("items")).Where(fsb.Gt("price", fsb.All(sub))).Dialect(fsb.SQLite).ToSQL()
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnsupported))
+}
+
+// Test_SingleElement tests that a comparison with one element is written without a group.
+func (s *QuantifiedSuite) Test_SingleElement() {
+	sql, err := fsb.Eq("id", fsb.All([]int{1})).ToSQL()
+
+	assert.Equal(s.T(), "id = 1", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_Invalid tests that a quantifier without a slice or a subquery is reported.
+func (s *QuantifiedSuite) Test_Invalid() {
+	_, err := fsb.Eq("id", fsb.Any(1)).ToSQL()
+	assert.EqualError(s.T(), err, "ANY needs a slice or a subquery but got int")
+}
+
+func TestQuantifiedSuite(t *testing.T) {
+	suite.Run(t, new(QuantifiedSuite))
+}
@@ -11,64 +11,92 @@ import (
 // It contains fields for the columns being selected (field),
 // hose from (table), condition (where), and a list of errors (errs).
 type SelectContainer struct {
-	field  []string
-	table  *TableContainer
-	joins  []*JoinContainer
-	where  *Expression
-	orders []*OrderContainer
-	limit  int
-	offset int
-	group  *GroupByContainer
-	having *Expression
-	errs   []error
+	field    []interface{}
+	table    *TableContainer
+	joins    []*JoinContainer
+	where    *Expression
+	orders   []*OrderContainer
+	limit    int
+	offset   int
+	group    *GroupByContainer
+	having   *Expression
+	distinct *DistinctContainer
+	seek     []interface{}
+	derived  *SelectContainer
+	lock     *LockContainer
+	dialect  Dialect
+	encoders *Encoders
+	errs     []error
 }
 
+// JoinContainer
+// This structure represents a join of the select statement.
+// The conditions are combined with AND into the ON clause, while using holds the columns of a USING clause.
+// For LATERAL joins, lateral is the correlated subquery and table carries its alias.
 type JoinContainer struct {
 	joinType   int
 	table      *TableContainer
 	conditions []*Expression
+	using      []string
+	lateral    *SelectContainer
 }
 
 type OrderContainer struct {
-	orderType      int
-	orderColumnStr string
+	orderType int
+	columns   []interface{}
+	nulls     int
+	collate   string
+}
+
+// DistinctContainer
+// This structure represents SELECT DISTINCT, or SELECT DISTINCT ON (columns) when columns are set.
+type DistinctContainer struct {
+	columns []interface{}
+}
+
+// LockContainer
+// This structure represents the row locking clause of a SELECT statement, such as FOR UPDATE OF users NOWAIT.
+type LockContainer struct {
+	strength int
+	tables   []*TableContainer
+	wait     int
 }
 
 type GroupByContainer struct {
-	groupColumnStr string
+	columns []interface{}
 }
 
 const (
-	inner = 1
-	left  = 2
-	right = 3
-	full  = 4
-	cross = 5
+	inner   = 1
+	left    = 2
+	right   = 3
+	full    = 4
+	cross   = 5
+	natural = 6
 
 	asc  = 1
 	desc = 2
+
+	nullsFirst = 1
+	nullsLast  = 2
+
+	lockUpdate      = 1
+	lockShare       = 2
+	lockNoKeyUpdate = 3
+
+	lockNoWait     = 1
+	lockSkipLocked = 2
+
+	derivedAlias = "count_query"
 )
 
 // Select
 // It initializes a new SelectContainer structure.
 // It takes all columns that should be selected. If no columns are passed, it assumes '*' (All columns).
 func Select(fields ...interface{}) *SelectContainer {
-	var f []string
-
-	if len(fields) > 0 {
-		for _, l := range fields {
-			switch v := l.(type) {
-			case string:
-				f = append(f, v)
-			case *ColumnContainer:
-				f = append(f, fmt.Sprintf("%s.%s", v.tName, v.col))
-			}
-		}
-	}
-
 	return &SelectContainer{
 		table:  &TableContainer{},
-		field:  f,
+		field:  append([]interface{}(nil), fields...),
 		joins:  []*JoinContainer{},
 		orders: []*OrderContainer{},
 		limit:  0,
@@ -79,20 +107,54 @@ func Select(fields ...interface{}) *SelectContainer {
 // From
 // It sets the table from which data has to be selected.
 // This method uses a fluent pattern,
-// meaning it returns the instance of the container itself,
-// allowing the calling of multiple methods in a single line (chaining of function calls).
+// meaning it returns a copy of the container with the change applied,
+// allowing the calling of multiple methods in a single line (chaining of function calls)
+// while leaving the original container untouched.
 func (s *SelectContainer) From(table *TableContainer) *SelectContainer {
-	s.table = table
+	c := s.Clone()
+	c.table = table
 
-	return s
+	return c
 }
 
 // Where
 // It sets the WHERE clause of the SQL SELECT statement.
 func (s *SelectContainer) Where(conditions *Expression) *SelectContainer {
-	s.where = conditions
+	c := s.Clone()
+	c.where = conditions
+
+	return c
+}
+
+// AndWhere
+// It adds the conditions to the WHERE clause of the SQL SELECT statement with the AND operator.
+// When no WHERE clause has been set yet, the conditions become the WHERE clause.
+// A nil expression is ignored, so optional filters can be chained directly.
+func (s *SelectContainer) AndWhere(conditions *Expression) *SelectContainer {
+	c := s.Clone()
+	c.where = And(c.where, conditions)
 
-	return s
+	return c
+}
+
+// OrWhere
+// It adds the conditions to the WHERE clause of the SQL SELECT statement with the OR operator.
+// A nil expression is ignored in the same way as AndWhere.
+func (s *SelectContainer) OrWhere(conditions *Expression) *SelectContainer {
+	c := s.Clone()
+	c.where = Or(c.where, conditions)
+
+	return c
+}
+
+// WhereIf
+// It adds the conditions to the WHERE clause with the AND operator only when cond is true.
+func (s *SelectContainer) WhereIf(cond bool, conditions *Expression) *SelectContainer {
+	if !cond {
+		return s.Clone()
+	}
+
+	return s.AndWhere(conditions)
 }
 
 // InnerJoin
@@ -101,7 +163,7 @@ func (s *SelectContainer) Where(conditions *Expression) *SelectContainer {
 // - table: the table to join with.
 // - conditions...: optional conditions for the join.
 // Returns:
-// - *SelectContainer: the modified SelectContainer instance with the new join added.
+// - *SelectContainer: a copy of the SelectContainer instance with the new join added.
 func (s *SelectContainer) InnerJoin(table *TableContainer, conditions ...*Expression) *SelectContainer {
 	join := JoinContainer{
 		joinType:   inner,
@@ -109,8 +171,9 @@ func (s *SelectContainer) InnerJoin(table *TableContainer, conditions ...*Expres
 		conditions: conditions,
 	}
 
-	s.joins = append(s.joins, &join)
-	return s
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
 }
 
 func (s *SelectContainer) LeftJoin(table *TableContainer, conditions ...*Expression) *SelectContainer {
@@ -120,8 +183,9 @@ func (s *SelectContainer) LeftJoin(table *TableContainer, conditions ...*Express
 		conditions: conditions,
 	}
 
-	s.joins = append(s.joins, &join)
-	return s
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
 }
 
 func (s *SelectContainer) RightJoin(table *TableContainer, conditions ...*Expression) *SelectContainer {
@@ -131,8 +195,9 @@ func (s *SelectContainer) RightJoin(table *TableContainer, conditions ...*Expres
 		conditions: conditions,
 	}
 
-	s.joins = append(s.joins, &join)
-	return s
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
 }
 
 func (s *SelectContainer) FullJoin(table *TableContainer, conditions ...*Expression) *SelectContainer {
@@ -142,8 +207,9 @@ func (s *SelectContainer) FullJoin(table *TableContainer, conditions ...*Express
 		conditions: conditions,
 	}
 
-	s.joins = append(s.joins, &join)
-	return s
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
 }
 
 func (s *SelectContainer) CrossJoin(table *TableContainer) *SelectContainer {
@@ -152,29 +218,166 @@ func (s *SelectContainer) CrossJoin(table *TableContainer) *SelectContainer {
 		table:    table,
 	}
 
-	s.joins = append(s.joins, &join)
-	return s
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
 }
 
-func (s *SelectContainer) Order(conditions ...interface{}) *SelectContainer {
-	orderStr := createOrderString(conditions)
+// JoinFK
+// It adds an INNER JOIN whose ON condition is derived from the foreign keys declared with ForeignKey,
+// either on the joined table or on a table already in the statement.
+// An error is reported when no relationship, or more than one, connects the table to the statement.
+//
+// Example usage:
+//
+//	Select().From(users).JoinFK(orders) // SELECT * FROM users INNER JOIN orders ON orders.user_id = users.id
+func (s *SelectContainer) JoinFK(table *TableContainer) *SelectContainer {
+	return s.joinFK(inner, table)
+}
+
+// LeftJoinFK
+// It is the same as JoinFK, but adds a LEFT JOIN.
+func (s *SelectContainer) LeftJoinFK(table *TableContainer) *SelectContainer {
+	return s.joinFK(left, table)
+}
+
+func (s *SelectContainer) joinFK(joinType int, table *TableContainer) *SelectContainer {
+	c := s.Clone()
+
+	condition, err := s.foreignKeyCondition(table)
+	if err != nil {
+		c.errs = append(c.errs, err)
+		return c
+	}
+
+	c.joins = append(c.joins, &JoinContainer{
+		joinType:   joinType,
+		table:      table,
+		conditions: []*Expression{condition},
+	})
+
+	return c
+}
+
+// foreignKeyCondition finds the only foreign key between the table and the tables already in the statement.
+func (s *SelectContainer) foreignKeyCondition(table *TableContainer) (*Expression, error) {
+	tables := []*TableContainer{}
+	if s.table != nil && s.table.name != "" {
+		tables = append(tables, s.table)
+	}
+	for _, join := range s.joins {
+		tables = append(tables, join.table)
+	}
 
+	var conditions []*Expression
+	for _, other := range tables {
+		for _, fk := range table.foreignKeys {
+			if fk.ref.tName == other.bName {
+				conditions = append(conditions, Eq(table.Col(fk.column), other.Col(fk.ref.col)))
+			}
+		}
+		for _, fk := range other.foreignKeys {
+			if fk.ref.tName == table.bName {
+				conditions = append(conditions, Eq(other.Col(fk.column), table.Col(fk.ref.col)))
+			}
+		}
+	}
+
+	switch len(conditions) {
+	case 0:
+		return nil, buildError("SELECT", "JOIN", CodeInvalidClause, "no foreign key between %s and the tables of the statement", table.name)
+	case 1:
+		return conditions[0], nil
+	default:
+		return nil, buildError("SELECT", "JOIN", CodeInvalidClause, "ambiguous foreign key between %s and the tables of the statement", table.name)
+	}
+}
+
+// NaturalJoin
+// It adds a NATURAL JOIN, which joins on all the columns with the same name in both tables.
+func (s *SelectContainer) NaturalJoin(table *TableContainer) *SelectContainer {
+	join := JoinContainer{
+		joinType: natural,
+		table:    table,
+	}
+
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
+}
+
+// LateralJoin
+// It adds an INNER JOIN LATERAL with a correlated subquery, which may refer to the tables joined before it.
+// Without conditions the join is written with ON true.
+// LATERAL is supported by PostgreSQL and MySQL 8.
+//
+// Example usage:
+//
+//	latest := Select().From(Table("orders")).Where(Eq("orders.user_id", users.Col("id"))).OrderDe("created_at").Limit(1)
+//	Select().From(users).LateralJoin(latest, "latest_order")
+//	// SELECT * FROM users INNER JOIN LATERAL (SELECT * FROM orders WHERE ... LIMIT 1) AS latest_order ON true
+func (s *SelectContainer) LateralJoin(sub *SelectContainer, alias string, conditions ...*Expression) *SelectContainer {
+	join := JoinContainer{
+		joinType:   inner,
+		table:      Table(alias),
+		conditions: conditions,
+		lateral:    sub,
+	}
+
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
+}
+
+// CrossJoinLateral
+// It adds a CROSS JOIN LATERAL with a correlated subquery. See LateralJoin.
+func (s *SelectContainer) CrossJoinLateral(sub *SelectContainer, alias string) *SelectContainer {
+	join := JoinContainer{
+		joinType: cross,
+		table:    Table(alias),
+		lateral:  sub,
+	}
+
+	c := s.Clone()
+	c.joins = append(c.joins, &join)
+	return c
+}
+
+// Using
+// It sets the USING clause of the last join, such as INNER JOIN orders USING (user_id).
+// It is used instead of join conditions, and cannot be combined with them.
+func (s *SelectContainer) Using(columns ...string) *SelectContainer {
+	c := s.Clone()
+
+	if len(c.joins) == 0 {
+		c.errs = append(c.errs, buildError("SELECT", "JOIN", CodeMissingClause, "no set join"))
+		return c
+	}
+
+	join := c.joins[len(c.joins)-1]
+	join.using = append(join.using, columns...)
+
+	return c
+}
+
+func (s *SelectContainer) Order(conditions ...interface{}) *SelectContainer {
 	order := OrderContainer{
-		orderType:      asc,
-		orderColumnStr: orderStr,
+		orderType: asc,
+		columns:   conditions,
 	}
 
-	s.orders = append(s.orders, &order)
-	return s
+	c := s.Clone()
+	c.orders = append(c.orders, &order)
+	return c
 }
 
-func createOrderString(conditions []interface{}) string {
+func createOrderString(r *renderer, conditions []interface{}) string {
 	orderStr := ""
 	for i, condition := range conditions {
 		if i > 0 {
-			orderStr = fmt.Sprintf("%s, %s", orderStr, ConvertColumn(condition, true))
+			orderStr = fmt.Sprintf("%s, %s", orderStr, r.orderColumn(condition))
 		} else {
-			orderStr = ConvertColumn(condition, true)
+			orderStr = r.orderColumn(condition)
 		}
 	}
 
@@ -182,95 +385,329 @@ func createOrderString(conditions []interface{}) string {
 }
 
 func (s *SelectContainer) ASC() *SelectContainer {
-	if len(s.orders) == 0 {
-		s.errs = append(s.errs, fmt.Errorf("no set order"))
-		return s
-	}
+	return s.setLastOrder(func(o *OrderContainer) {
+		o.orderType = asc
+	})
+}
 
-	orders := s.orders[len(s.orders)-1]
-	orders.orderType = asc
-	s.orders[len(s.orders)-1] = orders
+func (s *SelectContainer) DESC() *SelectContainer {
+	return s.setLastOrder(func(o *OrderContainer) {
+		o.orderType = desc
+	})
+}
 
-	return s
+// NullsFirst
+// It places NULL values before other values in the last order (NULLS FIRST).
+// On MySQL and SQL Server, which lack NULLS FIRST, it is emulated by ordering on whether the column is NULL.
+func (s *SelectContainer) NullsFirst() *SelectContainer {
+	return s.setLastOrder(func(o *OrderContainer) {
+		o.nulls = nullsFirst
+	})
 }
 
-func (s *SelectContainer) DESC() *SelectContainer {
-	if len(s.orders) == 0 {
-		s.errs = append(s.errs, fmt.Errorf("no set order"))
-		return s
+// NullsLast
+// It places NULL values after other values in the last order (NULLS LAST).
+// It is emulated on MySQL and SQL Server in the same way as NullsFirst.
+func (s *SelectContainer) NullsLast() *SelectContainer {
+	return s.setLastOrder(func(o *OrderContainer) {
+		o.nulls = nullsLast
+	})
+}
+
+// Collate
+// It sorts the last order with the given collation (ORDER BY name COLLATE "C").
+func (s *SelectContainer) Collate(collation string) *SelectContainer {
+	return s.setLastOrder(func(o *OrderContainer) {
+		o.collate = collation
+	})
+}
+
+// OrderField
+// It orders the rows by the position of the column value in values, similar to MySQL's ORDER BY FIELD(...).
+// It is rendered as a CASE expression on every dialect, so rows whose value is not listed come last.
+//
+// Example usage:
+//
+//	Select().From(Table("tasks")).OrderField("status", "urgent", "open")
+//	// ORDER BY CASE WHEN status = 'urgent' THEN 1 WHEN status = 'open' THEN 2 ELSE 3 END ASC
+func (s *SelectContainer) OrderField(column interface{}, values ...interface{}) *SelectContainer {
+	c := Case()
+	for i, v := range values {
+		c = c.When(Eq(column, v), i+1)
 	}
 
-	orders := s.orders[len(s.orders)-1]
-	orders.orderType = desc
-	s.orders[len(s.orders)-1] = orders
+	return s.OrderA(c.Else(len(values) + 1))
+}
 
-	return s
+func (s *SelectContainer) setLastOrder(set func(o *OrderContainer)) *SelectContainer {
+	c := s.Clone()
+	if len(c.orders) == 0 {
+		c.errs = append(c.errs, buildError("SELECT", "ORDER BY", CodeMissingClause, "no set order"))
+		return c
+	}
+
+	set(c.orders[len(c.orders)-1])
+
+	return c
 }
 
 func (s *SelectContainer) OrderA(conditions ...interface{}) *SelectContainer {
-	orderStr := createOrderString(conditions)
-
 	order := OrderContainer{
-		orderType:      asc,
-		orderColumnStr: orderStr,
+		orderType: asc,
+		columns:   conditions,
 	}
 
-	s.orders = append(s.orders, &order)
-	return s
+	c := s.Clone()
+	c.orders = append(c.orders, &order)
+	return c
 }
 
 func (s *SelectContainer) OrderDe(conditions ...interface{}) *SelectContainer {
-	orderStr := createOrderString(conditions)
-
 	order := OrderContainer{
-		orderType:      desc,
-		orderColumnStr: orderStr,
+		orderType: desc,
+		columns:   conditions,
 	}
 
-	s.orders = append(s.orders, &order)
-	return s
+	c := s.Clone()
+	c.orders = append(c.orders, &order)
+	return c
 }
 
 func (s *SelectContainer) Limit(count int) *SelectContainer {
-	s.limit = count
+	c := s.Clone()
+	c.limit = count
 
-	return s
+	return c
 }
 
 func (s *SelectContainer) Offset(count int) *SelectContainer {
-	s.offset = count
+	c := s.Clone()
+	c.offset = count
 
-	return s
+	return c
 }
 
-func (s *SelectContainer) GroupBy(conditions ...interface{}) *SelectContainer {
-	groupStr := createGroupByString(conditions)
+// GroupBy sets the GROUP BY clause.
+// It accepts the same items as OrderA, including aliases of the select list, function calls and CASE expressions,
+// and items that cannot be rendered are reported by ToSQL.
+func (s *SelectContainer) GroupBy(columns ...interface{}) *SelectContainer {
+	c := s.Clone()
+	c.group = &GroupByContainer{
+		columns: append([]interface{}(nil), columns...),
+	}
+
+	return c
+}
 
-	group := GroupByContainer{
-		groupColumnStr: groupStr,
+func (s *SelectContainer) Having(conditions *Expression) *SelectContainer {
+	c := s.Clone()
+	c.having = conditions
+
+	return c
+}
+
+// Distinct
+// It removes duplicate rows from the result (SELECT DISTINCT).
+func (s *SelectContainer) Distinct() *SelectContainer {
+	c := s.Clone()
+	c.distinct = &DistinctContainer{}
+
+	return c
+}
+
+// DistinctOn
+// It keeps only the first row of each group of rows with equal values in the given columns (SELECT DISTINCT ON).
+// DISTINCT ON is only available on PostgreSQL, and the columns must lead the ORDER BY list
+// built by Order, OrderA and OrderDe when one is set.
+func (s *SelectContainer) DistinctOn(columns ...interface{}) *SelectContainer {
+	c := s.Clone()
+	c.distinct = &DistinctContainer{
+		columns: columns,
 	}
 
-	s.group = &group
-	return s
+	return c
 }
 
-func createGroupByString(conditions []interface{}) string {
-	groupStr := ""
-	for i, condition := range conditions {
-		if i > 0 {
-			groupStr = fmt.Sprintf("%s, %s", groupStr, ConvertColumn(condition, true))
-		} else {
-			groupStr = ConvertColumn(condition, true)
-		}
+// CountQuery
+// It derives a query counting the rows the SelectContainer would return without pagination.
+// The FROM clause, joins, WHERE, GROUP BY and HAVING are kept,
+// while the select list, ORDER BY, LIMIT, OFFSET, Seek and row locks are dropped.
+// Grouped and DISTINCT queries count groups rather than rows,
+// so they are kept as a subquery: SELECT COUNT(*) FROM (SELECT ...) AS count_query.
+func (s *SelectContainer) CountQuery() *SelectContainer {
+	base := s.Clone()
+	base.orders = []*OrderContainer{}
+	base.limit = 0
+	base.offset = 0
+	base.seek = nil
+	base.lock = nil
+
+	if base.group != nil || base.having != nil || base.distinct != nil {
+		c := Select("COUNT(*)")
+		c.dialect = s.dialect
+		c.encoders = s.encoders
+		c.table = nil
+		c.derived = base
+
+		return c
 	}
 
-	return groupStr
+	base.field = []interface{}{"COUNT(*)"}
+
+	return base
 }
 
-func (s *SelectContainer) Having(conditions *Expression) *SelectContainer {
-	s.having = conditions
+// Dialect
+// It sets the database the SQL SELECT statement is generated for.
+// Clauses whose syntax differs between databases, such as row locking, are rendered for this dialect,
+// and so are all the subqueries of the statement, whatever dialect they were built with.
+func (s *SelectContainer) Dialect(d Dialect) *SelectContainer {
+	c := s.Clone()
+	c.dialect = d
+
+	return c
+}
+
+// Encoders
+// It sets the registry of encoders used for the values of the statement and of its subqueries.
+// A subquery with its own registry uses that one instead.
+// Without it, the registry of the enclosing statement is used, or DefaultEncoders for the outermost statement.
+func (s *SelectContainer) Encoders(encoders *Encoders) *SelectContainer {
+	c := s.Clone()
+	c.encoders = encoders
+
+	return c
+}
+
+// ForUpdate
+// It locks the selected rows for update (FOR UPDATE).
+// On SQL Server the lock is expressed with the WITH (UPDLOCK, ROWLOCK) table hint instead.
+func (s *SelectContainer) ForUpdate() *SelectContainer {
+	return s.setLock(lockUpdate)
+}
+
+// ForShare
+// It locks the selected rows in share mode (FOR SHARE).
+// On SQL Server the lock is expressed with the WITH (HOLDLOCK, ROWLOCK) table hint instead.
+func (s *SelectContainer) ForShare() *SelectContainer {
+	return s.setLock(lockShare)
+}
+
+// ForNoKeyUpdate
+// It locks the selected rows with FOR NO KEY UPDATE, which is only available on PostgreSQL.
+func (s *SelectContainer) ForNoKeyUpdate() *SelectContainer {
+	return s.setLock(lockNoKeyUpdate)
+}
 
-	return s
+func (s *SelectContainer) setLock(strength int) *SelectContainer {
+	c := s.Clone()
+	c.lock = &LockContainer{
+		strength: strength,
+	}
+
+	return c
+}
+
+// Of
+// It restricts the row lock to the given tables (FOR UPDATE OF users).
+// It must be called after ForUpdate, ForShare or ForNoKeyUpdate.
+func (s *SelectContainer) Of(tables ...*TableContainer) *SelectContainer {
+	c := s.Clone()
+	if c.lock == nil {
+		c.errs = append(c.errs, buildError("SELECT", "FOR", CodeMissingClause, "no set lock"))
+		return c
+	}
+
+	c.lock.tables = append(c.lock.tables, tables...)
+
+	return c
+}
+
+// NoWait
+// It makes the row lock fail immediately instead of waiting for rows locked by other transactions.
+// It must be called after ForUpdate, ForShare or ForNoKeyUpdate.
+func (s *SelectContainer) NoWait() *SelectContainer {
+	return s.setLockWait(lockNoWait)
+}
+
+// SkipLocked
+// It makes the row lock skip rows locked by other transactions, which is the usual choice for queue workers.
+// It must be called after ForUpdate, ForShare or ForNoKeyUpdate.
+func (s *SelectContainer) SkipLocked() *SelectContainer {
+	return s.setLockWait(lockSkipLocked)
+}
+
+func (s *SelectContainer) setLockWait(wait int) *SelectContainer {
+	c := s.Clone()
+	if c.lock == nil {
+		c.errs = append(c.errs, buildError("SELECT", "FOR", CodeMissingClause, "no set lock"))
+		return c
+	}
+
+	c.lock.wait = wait
+
+	return c
+}
+
+// Clone returns a deep copy of the SelectContainer.
+// Every builder method works on a clone, so a SelectContainer can be shared as a base query
+// and specialised by several callers, including from different goroutines, without affecting each other.
+func (s *SelectContainer) Clone() *SelectContainer {
+	c := *s
+
+	c.field = append([]interface{}(nil), s.field...)
+	c.table = s.table.clone()
+	c.where = s.where.Clone()
+	c.having = s.having.Clone()
+	c.errs = append([]error(nil), s.errs...)
+
+	c.joins = make([]*JoinContainer, len(s.joins))
+	for i, join := range s.joins {
+		j := *join
+		j.table = join.table.clone()
+		j.conditions = make([]*Expression, len(join.conditions))
+		for k, condition := range join.conditions {
+			j.conditions[k] = condition.Clone()
+		}
+		j.using = append([]string(nil), join.using...)
+		if join.lateral != nil {
+			j.lateral = join.lateral.Clone()
+		}
+		c.joins[i] = &j
+	}
+
+	c.orders = make([]*OrderContainer, len(s.orders))
+	for i, order := range s.orders {
+		o := *order
+		o.columns = append([]interface{}(nil), order.columns...)
+		c.orders[i] = &o
+	}
+
+	if s.group != nil {
+		c.group = &GroupByContainer{columns: append([]interface{}(nil), s.group.columns...)}
+	}
+
+	c.seek = append([]interface{}(nil), s.seek...)
+	if s.derived != nil {
+		c.derived = s.derived.Clone()
+	}
+
+	if s.distinct != nil {
+		d := *s.distinct
+		d.columns = append([]interface{}(nil), s.distinct.columns...)
+		c.distinct = &d
+	}
+
+	if s.lock != nil {
+		l := *s.lock
+		l.tables = make([]*TableContainer, len(s.lock.tables))
+		for i, table := range s.lock.tables {
+			l.tables[i] = table.clone()
+		}
+		c.lock = &l
+	}
+
+	return &c
 }
 
 // ToSQL
@@ -279,58 +716,172 @@ func (s *SelectContainer) Having(conditions *Expression) *SelectContainer {
 // it will return an empty string and the error.
 // The SQL string is composed by appending different components of the select statement.
 func (s *SelectContainer) ToSQL() (string, error) {
-	if len(s.errs) > 0 {
-		return "", errors.Join(s.errs...)
+	r := &renderer{dialect: s.dialect, encoders: s.encoders}
+	sql := s.createSQL(r)
+
+	if len(r.errs) > 0 {
+		return "", errors.Join(r.errs...)
+	}
+
+	return fmt.Sprintf("%s;", sql), nil
+}
+
+// Validate
+// It checks the structure of the SQL SELECT statement without generating it,
+// and returns the errors recorded by the builder methods together with a *BuildError for every invalid clause:
+// a missing FROM table, GROUP BY without columns, HAVING without GROUP BY and a negative LIMIT or OFFSET.
+// ToSQL reports the same errors together with the ones found while rendering the clauses.
+func (s *SelectContainer) Validate() error {
+	return errors.Join(append(append([]error(nil), s.errs...), s.validate()...)...)
+}
+
+// validate returns the structural errors of the statement.
+func (s *SelectContainer) validate() []error {
+	var errs []error
+
+	if s.derived == nil && (s.table == nil || s.table.name == "") && (len(s.field) == 0 || len(s.joins) > 0) {
+		errs = append(errs, buildError("SELECT", "FROM", CodeMissingClause, "no table set"))
+	}
+
+	if s.group != nil && len(s.group.columns) == 0 {
+		errs = append(errs, buildError("SELECT", "GROUP BY", CodeMissingClause, "no group column"))
+	}
+
+	if s.having != nil && s.group == nil {
+		errs = append(errs, buildError("SELECT", "HAVING", CodeInvalidClause, "HAVING requires GROUP BY"))
+	}
+
+	if s.limit < 0 {
+		errs = append(errs, buildError("SELECT", "LIMIT", CodeInvalidClause, "negative limit %d", s.limit))
+	}
+
+	if s.offset < 0 {
+		errs = append(errs, buildError("SELECT", "OFFSET", CodeInvalidClause, "negative offset %d", s.offset))
 	}
 
+	return errs
+}
+
+// createSQL builds the SELECT statement without the trailing semicolon, so that it can also be used as a subquery.
+// Errors are collected in the renderer, located at the clause being rendered.
+func (s *SelectContainer) createSQL(r *renderer) string {
+	statement, clause, encoders := r.statement, r.clause, r.encoders
+	defer func() { r.statement, r.clause, r.encoders = statement, clause, encoders }()
+
+	if s.encoders != nil {
+		r.encoders = s.encoders
+	}
+
+	r.errs = append(r.errs, s.errs...)
+	r.errs = append(r.errs, s.validate()...)
+	r.statement = "SELECT"
 	sqlElements := []string{"SELECT"}
 
+	if s.distinct != nil {
+		r.clause = "DISTINCT"
+		sqlElements = append(sqlElements, s.createDistinctSQL(r))
+	}
+
+	if r.dialect == SQLServer && s.limit > 0 && s.offset == 0 {
+		sqlElements = append(sqlElements, fmt.Sprintf("TOP %d", s.limit))
+	}
+
+	r.clause = "SELECT"
 	if len(s.field) > 0 {
-		sqlElements = append(sqlElements, strings.Join(s.field, ", "))
+		fields := make([]string, len(s.field))
+		for i, field := range s.field {
+			fields[i] = r.selectItem(field)
+		}
+		sqlElements = append(sqlElements, strings.Join(fields, ", "))
 	} else {
 		sqlElements = append(sqlElements, "*")
 	}
 
-	if s.table != nil {
+	r.clause = "FROM"
+	if s.derived != nil {
+		sqlElements = append(sqlElements, "FROM", fmt.Sprintf("(%s)", s.derived.createSQL(r)), "AS", derivedAlias)
+	} else if s.table != nil && s.table.name != "" {
 		if s.table.name != s.table.bName {
 			sqlElements = append(sqlElements, "FROM", s.table.bName, "AS", s.table.name)
 		} else {
 			sqlElements = append(sqlElements, "FROM", s.table.name)
 		}
+
+		if hint := s.createTableHint(r, s.table, true); hint != "" {
+			sqlElements = append(sqlElements, hint)
+		}
 	}
 
 	if len(s.joins) > 0 {
-		sqlElements = s.createJoinSQL(sqlElements)
+		r.clause = "JOIN"
+		s.checkTableAliases(r)
+		sqlElements = s.createJoinSQL(r, sqlElements)
 	}
 
-	if s.where != nil {
-		sqlElements = append(sqlElements, "WHERE", s.where.condition)
+	r.clause = "WHERE"
+	if where := And(s.where, s.createSeekCondition(r)); where != nil {
+		sqlElements = append(sqlElements, "WHERE", r.expression(where))
 	}
 
 	if s.group != nil {
-		sqlElements = append(sqlElements, "GROUP BY", s.group.groupColumnStr)
+		r.clause = "GROUP BY"
+		sqlElements = append(sqlElements, "GROUP BY", createOrderString(r, s.group.columns))
 	}
 
 	if s.having != nil {
-		sqlElements = append(sqlElements, "HAVING", s.having.condition)
+		r.clause = "HAVING"
+		sqlElements = append(sqlElements, "HAVING", r.expression(s.having))
 	}
 
 	if len(s.orders) > 0 {
-		sqlElements = s.createOrderSQL(sqlElements)
+		r.clause = "ORDER BY"
+		sqlElements = s.createOrderSQL(r, sqlElements)
 	}
 
-	if s.limit > 0 {
-		sqlElements = append(sqlElements, fmt.Sprintf("LIMIT %d", s.limit))
+	if r.dialect == SQLServer {
+		sqlElements = s.createFetchSQL(r, sqlElements)
+	} else {
+		if s.limit > 0 {
+			sqlElements = append(sqlElements, fmt.Sprintf("LIMIT %d", s.limit))
+		}
+
+		if s.offset > 0 {
+			sqlElements = append(sqlElements, fmt.Sprintf("OFFSET %d", s.offset))
+		}
+	}
+
+	if s.lock != nil {
+		r.clause = "FOR"
+		if lockStr := s.createLockSQL(r); lockStr != "" {
+			sqlElements = append(sqlElements, lockStr)
+		}
+	}
+
+	return strings.Join(sqlElements, " ")
+}
+
+// createFetchSQL appends the pagination of SQL Server, which has no LIMIT and OFFSET.
+// A limit alone is rendered as TOP after SELECT, and an offset as OFFSET n ROWS FETCH NEXT m ROWS ONLY,
+// which SQL Server only accepts after ORDER BY.
+func (s *SelectContainer) createFetchSQL(r *renderer, sqlElements []string) []string {
+	if s.offset <= 0 {
+		return sqlElements
 	}
 
-	if s.offset > 0 {
-		sqlElements = append(sqlElements, fmt.Sprintf("OFFSET %d", s.offset))
+	r.clause = "OFFSET"
+	if len(s.orders) == 0 {
+		r.fail(CodeInvalidClause, "OFFSET requires ORDER BY on %s", r.dialect)
+	}
+
+	sqlElements = append(sqlElements, fmt.Sprintf("OFFSET %d ROWS", s.offset))
+	if s.limit > 0 {
+		sqlElements = append(sqlElements, fmt.Sprintf("FETCH NEXT %d ROWS ONLY", s.limit))
 	}
 
-	return fmt.Sprintf("%s;", strings.Join(sqlElements, " ")), nil
+	return sqlElements
 }
 
-func (s *SelectContainer) createJoinSQL(sqlElements []string) []string {
+func (s *SelectContainer) createJoinSQL(r *renderer, sqlElements []string) []string {
 	for _, join := range s.joins {
 		joinTypeStr := ""
 		switch join.joinType {
@@ -344,41 +895,94 @@ func (s *SelectContainer) createJoinSQL(sqlElements []string) []string {
 			joinTypeStr = "FULL JOIN"
 		case cross:
 			joinTypeStr = "CROSS JOIN"
-		}
-
-		joinConditions := make([]string, len(join.conditions))
-		for i, condition := range join.conditions {
-			joinConditions[i] = condition.condition
+		case natural:
+			joinTypeStr = "NATURAL JOIN"
 		}
 
 		tn := join.table.name
-		if join.table.name != join.table.bName {
+		if join.lateral != nil {
+			tn = s.createLateralSQL(r, join)
+		} else if join.table.name != join.table.bName {
 			tn = fmt.Sprintf("%s AS %s", join.table.bName, join.table.name)
 		}
 
-		if len(joinConditions) > 0 {
-			sqlElements = append(
-				sqlElements,
-				fmt.Sprintf("%s %s ON %s", joinTypeStr, tn, strings.Join(joinConditions, " AND ")),
-			)
-		} else {
-			sqlElements = append(
-				sqlElements,
-				fmt.Sprintf("%s %s", joinTypeStr, tn),
-			)
+		if hint := s.createTableHint(r, join.table, false); hint != "" && join.lateral == nil {
+			tn = fmt.Sprintf("%s %s", tn, hint)
+		}
+
+		joinStr := fmt.Sprintf("%s %s", joinTypeStr, tn)
+		condition := And(join.conditions...)
+
+		switch {
+		case len(join.using) > 0:
+			if condition != nil || join.joinType == cross || join.joinType == natural {
+				r.fail(CodeInvalidClause, "USING cannot be combined with join conditions, CROSS JOIN or NATURAL JOIN")
+			}
+			if r.dialect == SQLServer {
+				r.fail(CodeUnsupported, "USING is not supported by %s", r.dialect)
+			}
+			joinStr = fmt.Sprintf("%s USING (%s)", joinStr, strings.Join(join.using, ", "))
+		case condition != nil:
+			joinStr = fmt.Sprintf("%s ON %s", joinStr, r.expression(condition))
+		case join.lateral != nil && join.joinType != cross:
+			joinStr = fmt.Sprintf("%s ON true", joinStr)
 		}
+
+		if join.joinType == natural && r.dialect == SQLServer {
+			r.fail(CodeUnsupported, "NATURAL JOIN is not supported by %s", r.dialect)
+		}
+
+		sqlElements = append(sqlElements, joinStr)
 	}
 
 	return sqlElements
 }
 
-func (s *SelectContainer) createOrderSQL(elements []string) []string {
+// createLateralSQL renders the LATERAL subquery of a join together with its alias.
+func (s *SelectContainer) createLateralSQL(r *renderer, join *JoinContainer) string {
+	switch r.dialect {
+	case SQLite, SQLServer:
+		r.fail(CodeUnsupported, "LATERAL joins are not supported by %s", r.dialect)
+	}
+
+	if !identPattern.MatchString(join.table.name) {
+		r.fail(CodeInvalidIdentifier, "invalid alias %q", join.table.name)
+	}
+
+	return fmt.Sprintf("LATERAL %s AS %s", r.subquery(join.lateral), join.table.name)
+}
+
+func (s *SelectContainer) createOrderSQL(r *renderer, elements []string) []string {
 	orderStr := "ORDER BY"
 	for i, order := range s.orders {
 		if i > 0 {
 			orderStr = fmt.Sprintf("%s,", orderStr)
 		}
-		orderStr = fmt.Sprintf("%s %s", orderStr, order.orderColumnStr)
+
+		if len(order.columns) == 0 {
+			r.fail(CodeMissingClause, "no order column")
+			continue
+		}
+
+		// ASC, DESC, COLLATE and NULLS FIRST/LAST only apply to the last column of an order.
+		last := order.columns[len(order.columns)-1]
+		if len(order.columns) > 1 {
+			orderStr = fmt.Sprintf("%s %s,", orderStr, createOrderString(r, order.columns[:len(order.columns)-1]))
+		}
+
+		lastStr := r.orderColumn(last)
+		if nullsStr := s.createNullsEmulation(r, order, lastStr); nullsStr != "" {
+			orderStr = fmt.Sprintf("%s %s,", orderStr, nullsStr)
+		}
+
+		orderStr = fmt.Sprintf("%s %s", orderStr, lastStr)
+
+		if order.collate != "" {
+			if !identPattern.MatchString(order.collate) {
+				r.fail(CodeInvalidIdentifier, "invalid collation %q", order.collate)
+			}
+			orderStr = fmt.Sprintf("%s COLLATE %s", orderStr, order.collate)
+		}
 
 		switch order.orderType {
 		case asc:
@@ -386,9 +990,210 @@ func (s *SelectContainer) createOrderSQL(elements []string) []string {
 		case desc:
 			orderStr = fmt.Sprintf("%s %s", orderStr, "DESC")
 		}
+
+		if r.dialect != MySQL && r.dialect != SQLServer {
+			switch order.nulls {
+			case nullsFirst:
+				orderStr = fmt.Sprintf("%s %s", orderStr, "NULLS FIRST")
+			case nullsLast:
+				orderStr = fmt.Sprintf("%s %s", orderStr, "NULLS LAST")
+			}
+		}
 	}
 
 	elements = append(elements, orderStr)
 
 	return elements
 }
+
+// createNullsEmulation returns the extra ORDER BY item that places NULL values first or last
+// on dialects without NULLS FIRST/LAST. MySQL sorts on `column IS NULL`, SQL Server on a CASE expression.
+func (s *SelectContainer) createNullsEmulation(r *renderer, order *OrderContainer, column string) string {
+	if order.nulls == 0 || (r.dialect != MySQL && r.dialect != SQLServer) {
+		return ""
+	}
+
+	direction := "ASC"
+	if order.nulls == nullsFirst {
+		direction = "DESC"
+	}
+
+	if r.dialect == SQLServer {
+		return fmt.Sprintf("CASE WHEN %s IS NULL THEN 1 ELSE 0 END %s", column, direction)
+	}
+
+	return fmt.Sprintf("%s IS NULL %s", column, direction)
+}
+
+// createDistinctSQL returns the DISTINCT or DISTINCT ON keyword placed after SELECT.
+// For DISTINCT ON it checks the dialect and that the leading ORDER BY columns are DISTINCT ON columns.
+func (s *SelectContainer) createDistinctSQL(r *renderer) string {
+	if len(s.distinct.columns) == 0 {
+		return "DISTINCT"
+	}
+
+	if r.dialect != Generic && r.dialect != PostgreSQL {
+		r.fail(CodeUnsupported, "DISTINCT ON is not supported by %s", r.dialect)
+		return ""
+	}
+
+	distinctStrs := make([]string, len(s.distinct.columns))
+	distinctColumns := map[string]bool{}
+	for i, column := range s.distinct.columns {
+		distinctStrs[i] = r.orderColumn(column)
+		distinctColumns[distinctStrs[i]] = true
+	}
+
+	// The ORDER BY items are only compared here. Their errors are reported when ORDER BY is rendered,
+	// and the comparison is skipped when one of them cannot be rendered.
+	scratch := &renderer{dialect: r.dialect, encoders: r.encoders, statement: r.statement, clause: r.clause}
+	var orderColumns []string
+	for _, order := range s.orders {
+		for _, column := range order.columns {
+			orderColumns = append(orderColumns, scratch.orderColumn(column))
+		}
+	}
+
+	for i, column := range orderColumns {
+		if i >= len(s.distinct.columns) || len(scratch.errs) > 0 {
+			break
+		}
+		if !distinctColumns[column] {
+			r.fail(CodeInvalidClause, "DISTINCT ON columns must match the leading ORDER BY columns: %s", column)
+			break
+		}
+	}
+
+	return fmt.Sprintf("DISTINCT ON (%s)", strings.Join(distinctStrs, ", "))
+}
+
+// createLockSQL returns the row locking clause placed at the end of the statement.
+// It returns an empty string for SQL Server, where the lock is rendered as table hints by createTableHint.
+func (s *SelectContainer) createLockSQL(r *renderer) string {
+	if s.group != nil || s.having != nil || s.distinct != nil {
+		r.fail(CodeInvalidClause, "row locking is not allowed with DISTINCT, GROUP BY or HAVING")
+		return ""
+	}
+
+	for _, table := range s.lock.tables {
+		if !s.hasTable(table) {
+			r.fail(CodeInvalidClause, "lock table %s is not used in the statement", table.name)
+		}
+	}
+
+	switch r.dialect {
+	case SQLite:
+		r.fail(CodeUnsupported, "row locking is not supported by %s", r.dialect)
+		return ""
+	case SQLServer:
+		if s.lock.strength == lockNoKeyUpdate {
+			r.fail(CodeUnsupported, "FOR NO KEY UPDATE is not supported by %s", r.dialect)
+		}
+		return ""
+	}
+
+	lockStr := ""
+	switch s.lock.strength {
+	case lockUpdate:
+		lockStr = "FOR UPDATE"
+	case lockShare:
+		lockStr = "FOR SHARE"
+	case lockNoKeyUpdate:
+		if r.dialect == MySQL {
+			r.fail(CodeUnsupported, "FOR NO KEY UPDATE is not supported by %s", r.dialect)
+			return ""
+		}
+		lockStr = "FOR NO KEY UPDATE"
+	}
+
+	if len(s.lock.tables) > 0 {
+		names := make([]string, len(s.lock.tables))
+		for i, table := range s.lock.tables {
+			names[i] = table.name
+		}
+		lockStr = fmt.Sprintf("%s OF %s", lockStr, strings.Join(names, ", "))
+	}
+
+	switch s.lock.wait {
+	case lockNoWait:
+		lockStr = fmt.Sprintf("%s %s", lockStr, "NOWAIT")
+	case lockSkipLocked:
+		lockStr = fmt.Sprintf("%s %s", lockStr, "SKIP LOCKED")
+	}
+
+	return lockStr
+}
+
+// createTableHint returns the SQL Server table hint that expresses the row lock for the given table.
+// Without Of, only the table in the FROM clause is locked.
+func (s *SelectContainer) createTableHint(r *renderer, table *TableContainer, from bool) string {
+	if s.lock == nil || r.dialect != SQLServer {
+		return ""
+	}
+
+	if len(s.lock.tables) > 0 {
+		found := false
+		for _, t := range s.lock.tables {
+			if t.name == table.name {
+				found = true
+			}
+		}
+		if !found {
+			return ""
+		}
+	} else if !from {
+		return ""
+	}
+
+	var hints []string
+	switch s.lock.strength {
+	case lockUpdate:
+		hints = append(hints, "UPDLOCK", "ROWLOCK")
+	case lockShare:
+		hints = append(hints, "HOLDLOCK", "ROWLOCK")
+	default:
+		return ""
+	}
+
+	switch s.lock.wait {
+	case lockNoWait:
+		hints = append(hints, "NOWAIT")
+	case lockSkipLocked:
+		hints = append(hints, "READPAST")
+	}
+
+	return fmt.Sprintf("WITH (%s)", strings.Join(hints, ", "))
+}
+
+// checkTableAliases reports tables of the FROM clause and the joins that share a name or alias,
+// such as a self-join in which one side is not aliased, because their columns could not be told apart.
+func (s *SelectContainer) checkTableAliases(r *renderer) {
+	names := map[string]bool{}
+	if s.derived != nil {
+		names[derivedAlias] = true
+	} else if s.table != nil && s.table.name != "" {
+		names[s.table.name] = true
+	}
+
+	for _, join := range s.joins {
+		if names[join.table.name] {
+			r.fail(CodeInvalidClause, "duplicate table alias %s", join.table.name)
+		}
+		names[join.table.name] = true
+	}
+}
+
+// hasTable reports whether the table, identified by its alias, is used in the FROM clause or a join.
+func (s *SelectContainer) hasTable(table *TableContainer) bool {
+	if s.table != nil && s.table.name == table.name {
+		return true
+	}
+
+	for _, join := range s.joins {
+		if join.table.name == table.name {
+			return true
+		}
+	}
+
+	return false
+}
@@ -1,7 +1,10 @@
 package fsb_test
 
 import (
+	"errors"
+	"fmt"
 	"fsb"
+	"sync"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -120,8 +123,9 @@ func (s *SelectSuite) Test_SelectString_WhereOrAnd() {
 
 // Test_SelectString_WhereMulti tests the SelectString_WhereMulti method in the SelectSuite struct.
 // Selects from the "users" table with the following conditions:
-// - "name" equals "test" OR ("id" equals 1 AND "id" equals 2)
+// - ("name" equals "test" OR ("id" equals 1 AND "id" equals 2))
 // - AND ("login_id" equals "test2" AND "name" equals "name2")
+// The OR chain on the left must stay bracketed so that AND does not bind to its last operand only.
 func (s *SelectSuite) Test_SelectString_WhereMulti() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
@@ -138,7 +142,7 @@ func (s *SelectSuite) Test_SelectString_WhereMulti() {
 
 	assert.Equal(
 		s.T(),
-		"SELECT * FROM users WHERE name = 'test' OR (id = 1 AND id = 2) AND login_id = 'test2' AND name = 'name2';",
+		"SELECT * FROM users WHERE (name = 'test' OR (id = 1 AND id = 2)) AND login_id = 'test2' AND name = 'name2';",
 		sql,
 	)
 	assert.Nil(s.T(), err)
@@ -717,16 +721,65 @@ func (s *SelectSuite) Test_SelectString_GroupByColumn() {
 	assert.Nil(s.T(), err)
 }
 
+// Test_SelectString_SubqueryDialect tests that subqueries in conditions are rendered for the dialect of the statement.
+func (s *SelectSuite) Test_SelectString_SubqueryDialect() {
+	locked := fsb.Select("id").From(fsb.Table("orders")).ForUpdate()
+
+	sql, err := fsb.Select().From(fsb.Table("users")).Where(fsb.In("id", locked)).Dialect(fsb.SQLServer).ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users WHERE id IN (SELECT id FROM orders WITH (UPDLOCK, ROWLOCK));", sql)
+	assert.Nil(s.T(), err)
+
+	latest := fsb.Select("user_id").From(fsb.Table("logs")).DistinctOn("user_id")
+
+	sql, err = fsb.Select().From(fsb.Table("users")).Where(fsb.Eq("id", fsb.Any(latest))).Dialect(fsb.MySQL).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnsupported))
+}
+
+// Test_SelectString_GroupByAlias tests grouping by an alias of the select list and by a function call.
+func (s *SelectSuite) Test_SelectString_GroupByAlias() {
+	day := fsb.Alias(fsb.Func("DATE", "created_at"), "day")
+	cnt := fsb.Alias("COUNT(*)", "cnt")
+
+	sql, err := fsb.Select(day, cnt).From(fsb.Table("orders")).GroupBy(day).Having(fsb.Gt(cnt, 1)).ToSQL()
+
+	assert.Equal(s.T(), "SELECT DATE(created_at) AS day, COUNT(*) AS cnt FROM orders GROUP BY day HAVING cnt > 1;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select("status").From(fsb.Table("orders")).GroupBy(fsb.Func("LOWER", "status")).ToSQL()
+
+	assert.Equal(s.T(), "SELECT status FROM orders GROUP BY LOWER(status);", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select("status").From(fsb.Table("orders")).GroupBy(fsb.Func("bad name", "status")).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.True(s.T(), errors.Is(err, fsb.ErrInvalidIdentifier))
+	assert.Contains(s.T(), err.Error(), "SELECT GROUP BY: ")
+}
+
+// Test_SelectString_GroupByEmpty tests that GROUP BY without columns is reported.
+func (s *SelectSuite) Test_SelectString_GroupByEmpty() {
+	sql, err := fsb.Select().From(fsb.Table("users")).GroupBy().ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT GROUP BY: no group column")
+	assert.True(s.T(), errors.Is(err, fsb.ErrMissingClause))
+}
+
 // Test_SelectString_HavingInt tests the SelectString method in the SelectSuite struct
 // with a HAVING clause that compares the "id" column to the integer 1.
 func (s *SelectSuite) Test_SelectString_HavingInt() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("id", 1))
 
 	sql, err := sb.ToSQL()
 
-	assert.Equal(s.T(), "SELECT * FROM users HAVING id = 1;", sql)
+	assert.Equal(s.T(), "SELECT * FROM users GROUP BY name, id, login_id HAVING id = 1;", sql)
 	assert.Nil(s.T(), err)
 }
 
@@ -734,22 +787,24 @@ func (s *SelectSuite) Test_SelectString_HavingInt() {
 func (s *SelectSuite) Test_SelectString_HavingString() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("name", "test"))
 
 	sql, err := sb.ToSQL()
 
-	assert.Equal(s.T(), "SELECT * FROM users HAVING name = 'test';", sql)
+	assert.Equal(s.T(), "SELECT * FROM users GROUP BY name, id, login_id HAVING name = 'test';", sql)
 	assert.Nil(s.T(), err)
 }
 
 func (s *SelectSuite) Test_SelectString_HavingAND() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("name", "test").AND(fsb.Eq("id", 1)))
 
 	sql, err := sb.ToSQL()
 
-	assert.Equal(s.T(), "SELECT * FROM users HAVING name = 'test' AND id = 1;", sql)
+	assert.Equal(s.T(), "SELECT * FROM users GROUP BY name, id, login_id HAVING name = 'test' AND id = 1;", sql)
 	assert.Nil(s.T(), err)
 }
 
@@ -757,11 +812,12 @@ func (s *SelectSuite) Test_SelectString_HavingAND() {
 func (s *SelectSuite) Test_SelectString_HavingOR() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("name", "test").OR(fsb.Eq("id", 1)))
 
 	sql, err := sb.ToSQL()
 
-	assert.Equal(s.T(), "SELECT * FROM users HAVING name = 'test' OR id = 1;", sql)
+	assert.Equal(s.T(), "SELECT * FROM users GROUP BY name, id, login_id HAVING name = 'test' OR id = 1;", sql)
 	assert.Nil(s.T(), err)
 }
 
@@ -770,6 +826,7 @@ func (s *SelectSuite) Test_SelectString_HavingOR() {
 func (s *SelectSuite) Test_SelectString_HavingAndOr() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("name", "test").
 			AND(
 				fsb.Eq("id", 1).OR(fsb.Eq("id", 2)),
@@ -778,7 +835,7 @@ func (s *SelectSuite) Test_SelectString_HavingAndOr() {
 
 	sql, err := sb.ToSQL()
 
-	assert.Equal(s.T(), "SELECT * FROM users HAVING name = 'test' AND (id = 1 OR id = 2);", sql)
+	assert.Equal(s.T(), "SELECT * FROM users GROUP BY name, id, login_id HAVING name = 'test' AND (id = 1 OR id = 2);", sql)
 	assert.Nil(s.T(), err)
 }
 
@@ -787,6 +844,7 @@ func (s *SelectSuite) Test_SelectString_HavingAndOr() {
 func (s *SelectSuite) Test_SelectString_HavingOrAnd() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("name", "test").
 			OR(
 				fsb.Eq("id", 1).AND(fsb.Eq("id", 2)),
@@ -795,7 +853,7 @@ func (s *SelectSuite) Test_SelectString_HavingOrAnd() {
 
 	sql, err := sb.ToSQL()
 
-	assert.Equal(s.T(), "SELECT * FROM users HAVING name = 'test' OR (id = 1 AND id = 2);", sql)
+	assert.Equal(s.T(), "SELECT * FROM users GROUP BY name, id, login_id HAVING name = 'test' OR (id = 1 AND id = 2);", sql)
 	assert.Nil(s.T(), err)
 }
 
@@ -803,6 +861,7 @@ func (s *SelectSuite) Test_SelectString_HavingOrAnd() {
 func (s *SelectSuite) Test_SelectString_HavingMulti() {
 	sb := fsb.Select().
 		From(fsb.Table("users")).
+		GroupBy("name", "id", "login_id").
 		Having(fsb.Eq("name", "test").
 			OR(
 				fsb.Eq("id", 1).AND(fsb.Eq("id", 2)),
@@ -816,10 +875,631 @@ func (s *SelectSuite) Test_SelectString_HavingMulti() {
 
 	assert.Equal(
 		s.T(),
-		"SELECT * FROM users HAVING name = 'test' OR (id = 1 AND id = 2) AND login_id = 'test2' AND name = 'name2';",
+		"SELECT * FROM users GROUP BY name, id, login_id HAVING (name = 'test' OR (id = 1 AND id = 2)) AND login_id = 'test2' AND name = 'name2';",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_SharedBase tests that specialising a base query does not change the base query.
+func (s *SelectSuite) Test_SelectString_SharedBase() {
+	base := fsb.Select().From(fsb.Table("users")).Where(fsb.Eq("deleted", false)).OrderA("id")
+
+	active := base.Where(fsb.Eq("deleted", false).AND(fsb.Eq("status", "active"))).Limit(10)
+	desc := base.DESC()
+
+	sql, err := base.ToSQL()
+	assert.Equal(s.T(), "SELECT * FROM users WHERE deleted = false ORDER BY id ASC;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = active.ToSQL()
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users WHERE deleted = false AND status = 'active' ORDER BY id ASC LIMIT 10;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+
+	sql, err = desc.ToSQL()
+	assert.Equal(s.T(), "SELECT * FROM users WHERE deleted = false ORDER BY id DESC;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_Concurrent tests that a base query can be specialised from several goroutines at once.
+func (s *SelectSuite) Test_SelectString_Concurrent() {
+	base := fsb.Select().From(fsb.Table("users")).OrderA("id")
+
+	var wg sync.WaitGroup
+	results := make([]string, 20)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			results[i], _ = base.Where(fsb.Eq("id", i)).Limit(i + 1).ToSQL()
+		}(i)
+	}
+	wg.Wait()
+
+	for i, sql := range results {
+		assert.Equal(s.T(), fmt.Sprintf("SELECT * FROM users WHERE id = %d ORDER BY id ASC LIMIT %d;", i, i+1), sql)
+	}
+}
+
+// Test_SelectString_AndWhere tests that AndWhere accumulates conditions and skips nil expressions.
+func (s *SelectSuite) Test_SelectString_AndWhere() {
+	var name *string
+	age := 18
+
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		AndWhere(fsb.EqIfNotZero("name", name)).
+		AndWhere(fsb.GteIfNotZero("age", &age)).
+		AndWhere(fsb.InIfNotEmpty("status", []string{"active", "pending"})).
+		AndWhere(fsb.InIfNotEmpty("id", []int{}))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users WHERE age >= 18 AND status IN ('active', 'pending');", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_OrWhere tests that OrWhere brackets the accumulated AND conditions.
+func (s *SelectSuite) Test_SelectString_OrWhere() {
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		Where(fsb.Eq("name", "test")).
+		AndWhere(fsb.Eq("age", 20)).
+		OrWhere(fsb.Eq("id", 1))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users WHERE (name = 'test' AND age = 20) OR id = 1;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_WhereIf tests that WhereIf only adds the condition when the flag is true.
+func (s *SelectSuite) Test_SelectString_WhereIf() {
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		WhereIf(true, fsb.Eq("deleted", false)).
+		WhereIf(false, fsb.Eq("id", 1))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users WHERE deleted = false;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_AndWhereEmpty tests that no WHERE clause is generated when every optional filter is skipped.
+func (s *SelectSuite) Test_SelectString_AndWhereEmpty() {
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		AndWhere(fsb.EqIfNotZero("name", "")).
+		AndWhere(fsb.LtIfNotZero("age", 0))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_ForUpdate tests the FOR UPDATE clause with OF and SKIP LOCKED.
+func (s *SelectSuite) Test_SelectString_ForUpdate() {
+	job := fsb.Table("jobs")
+
+	sb := fsb.Select().
+		From(job).
+		Where(fsb.Eq("status", "queued")).
+		Limit(10).
+		ForUpdate().
+		Of(job).
+		SkipLocked()
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM jobs WHERE status = 'queued' LIMIT 10 FOR UPDATE OF jobs SKIP LOCKED;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_ForShare tests the FOR SHARE clause with NOWAIT on MySQL.
+func (s *SelectSuite) Test_SelectString_ForShare() {
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		Dialect(fsb.MySQL).
+		ForShare().
+		NoWait()
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users FOR SHARE NOWAIT;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_ForNoKeyUpdate tests that FOR NO KEY UPDATE is only generated for PostgreSQL.
+func (s *SelectSuite) Test_SelectString_ForNoKeyUpdate() {
+	sb := fsb.Select().From(fsb.Table("users")).ForNoKeyUpdate()
+
+	sql, err := sb.Dialect(fsb.PostgreSQL).ToSQL()
+	assert.Equal(s.T(), "SELECT * FROM users FOR NO KEY UPDATE;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = sb.Dialect(fsb.MySQL).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_SelectString_ForUpdateSQLServer tests that SQL Server locks are rendered as table hints.
+func (s *SelectSuite) Test_SelectString_ForUpdateSQLServer() {
+	user := fsb.Table("users").As("u")
+	token := fsb.Table("tokens").As("t")
+
+	sb := fsb.Select().
+		From(user).
+		InnerJoin(token, fsb.Eq(user.Col("id"), token.Col("user_id"))).
+		Dialect(fsb.SQLServer).
+		ForUpdate().
+		Of(token).
+		SkipLocked()
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users AS u INNER JOIN tokens AS t WITH (UPDLOCK, ROWLOCK, READPAST) ON u.id = t.user_id;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_SQLServerPaging tests that SQL Server pages with TOP and OFFSET FETCH instead of LIMIT and OFFSET.
+func (s *SelectSuite) Test_SelectString_SQLServerPaging() {
+	jobs := fsb.Select().From(fsb.Table("jobs")).Dialect(fsb.SQLServer).ForUpdate().SkipLocked()
+
+	sql, err := jobs.Limit(10).ToSQL()
+
+	assert.Equal(s.T(), "SELECT TOP 10 * FROM jobs WITH (UPDLOCK, ROWLOCK, READPAST);", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = jobs.OrderA("id").Limit(10).Offset(20).ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM jobs WITH (UPDLOCK, ROWLOCK, READPAST) ORDER BY id ASC OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY;", sql)
+	assert.Nil(s.T(), err)
+
+	_, err = jobs.Offset(20).ToSQL()
+
+	assert.EqualError(s.T(), err, "SELECT OFFSET: OFFSET requires ORDER BY on SQL Server")
+}
+
+// Test_SelectString_ForUpdateRejected tests that locks are rejected where the statement does not allow them.
+func (s *SelectSuite) Test_SelectString_ForUpdateRejected() {
+	sql, err := fsb.Select().From(fsb.Table("users")).GroupBy("id").ForUpdate().ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).ForUpdate().Dialect(fsb.SQLite).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).ForUpdate().Of(fsb.Table("tokens")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).SkipLocked().ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT FOR: no set lock")
+}
+
+// Test_SelectString_Distinct tests the SELECT DISTINCT statement.
+func (s *SelectSuite) Test_SelectString_Distinct() {
+	sb := fsb.Select("name").From(fsb.Table("users")).Distinct()
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT DISTINCT name FROM users;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_DistinctOn tests the SELECT DISTINCT ON statement whose columns lead the ORDER BY list.
+func (s *SelectSuite) Test_SelectString_DistinctOn() {
+	log := fsb.Table("logs")
+
+	sb := fsb.Select().
+		From(log).
+		Dialect(fsb.PostgreSQL).
+		DistinctOn(log.Col("user_id")).
+		OrderA(log.Col("user_id")).
+		OrderDe(log.Col("created_at"))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT DISTINCT ON (logs.user_id) * FROM logs ORDER BY logs.user_id ASC, logs.created_at DESC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_DistinctOnRejected tests that DISTINCT ON is rejected when the ORDER BY list does not start
+// with its columns, and on dialects that do not support it.
+func (s *SelectSuite) Test_SelectString_DistinctOnRejected() {
+	sb := fsb.Select().From(fsb.Table("logs")).DistinctOn("user_id").OrderDe("created_at", "user_id")
+
+	sql, err := sb.ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("logs")).DistinctOn("user_id").Dialect(fsb.MySQL).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("logs")).Distinct().ForUpdate().ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_SelectString_CountQuery tests that the count query keeps the filter and drops the pagination.
+func (s *SelectSuite) Test_SelectString_CountQuery() {
+	user := fsb.Table("users").As("u")
+	token := fsb.Table("tokens").As("t")
+
+	sb := fsb.Select(user.Col("id"), user.Col("name")).
+		From(user).
+		InnerJoin(token, fsb.Eq(user.Col("id"), token.Col("user_id"))).
+		Where(fsb.Eq(user.Col("status"), "active")).
+		OrderDe(user.Col("id")).
+		Limit(20).
+		Offset(40)
+
+	sql, err := sb.CountQuery().ToSQL()
+	assert.Equal(
+		s.T(),
+		"SELECT COUNT(*) FROM users AS u INNER JOIN tokens AS t ON u.id = t.user_id WHERE u.status = 'active';",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+
+	sql, err = sb.ToSQL()
+	assert.Equal(
+		s.T(),
+		"SELECT u.id, u.name FROM users AS u INNER JOIN tokens AS t ON u.id = t.user_id WHERE u.status = 'active' "+
+			"ORDER BY u.id DESC LIMIT 20 OFFSET 40;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_CountQueryGrouped tests that grouped queries are counted through a subquery.
+func (s *SelectSuite) Test_SelectString_CountQueryGrouped() {
+	sb := fsb.Select("user_id").
+		From(fsb.Table("orders")).
+		Where(fsb.Gt("amount", 100)).
+		GroupBy("user_id").
+		Having(fsb.Gt("COUNT(*)", 2)).
+		OrderA("user_id").
+		Limit(10)
+
+	sql, err := sb.CountQuery().ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT COUNT(*) FROM (SELECT user_id FROM orders WHERE amount > 100 GROUP BY user_id HAVING COUNT(*) > 2) AS count_query;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_CountQueryDistinct tests that DISTINCT queries are counted through a subquery.
+func (s *SelectSuite) Test_SelectString_CountQueryDistinct() {
+	sb := fsb.Select("name").From(fsb.Table("users")).Distinct().ForShare()
+
+	sql, err := sb.CountQuery().ToSQL()
+
+	assert.Equal(s.T(), "SELECT COUNT(*) FROM (SELECT DISTINCT name FROM users) AS count_query;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_OrderNulls tests NULLS FIRST and NULLS LAST.
+func (s *SelectSuite) Test_SelectString_OrderNulls() {
+	sb := fsb.Select().From(fsb.Table("users")).OrderDe("last_login").NullsLast().OrderA("name").NullsFirst()
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users ORDER BY last_login DESC NULLS LAST, name ASC NULLS FIRST;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_OrderNullsEmulated tests that NULLS FIRST and NULLS LAST are emulated on MySQL and SQL Server.
+func (s *SelectSuite) Test_SelectString_OrderNullsEmulated() {
+	sb := fsb.Select().From(fsb.Table("users")).OrderDe("last_login").NullsLast()
+
+	sql, err := sb.Dialect(fsb.MySQL).ToSQL()
+	assert.Equal(s.T(), "SELECT * FROM users ORDER BY last_login IS NULL ASC, last_login DESC;", sql)
+	assert.Nil(s.T(), err)
+
+	sql, err = sb.NullsFirst().Dialect(fsb.SQLServer).ToSQL()
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users ORDER BY CASE WHEN last_login IS NULL THEN 1 ELSE 0 END DESC, last_login DESC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_OrderExpression tests ordering by a function call, a condition and a CASE expression.
+func (s *SelectSuite) Test_SelectString_OrderExpression() {
+	user := fsb.Table("users")
+
+	sb := fsb.Select().
+		From(user).
+		OrderDe(fsb.Eq(user.Col("status"), "vip")).
+		OrderA(fsb.Func("LOWER", user.Col("name"))).Collate(`"C"`).
+		OrderA(fsb.Case().When(fsb.IsNull(user.Col("email")), 1).Else(0))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		`SELECT * FROM users ORDER BY users.status = 'vip' DESC, LOWER(users.name) COLLATE "C" ASC, `+
+			`CASE WHEN users.email IS NULL THEN 1 ELSE 0 END ASC;`,
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_OrderField tests ordering by an explicit list of values.
+func (s *SelectSuite) Test_SelectString_OrderField() {
+	sb := fsb.Select().From(fsb.Table("tasks")).OrderField("status", "urgent", "open").OrderA("id")
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM tasks ORDER BY CASE WHEN status = 'urgent' THEN 1 WHEN status = 'open' THEN 2 ELSE 3 END ASC, id ASC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_OrderRejected tests that unsupported order items and invalid collations are reported.
+func (s *SelectSuite) Test_SelectString_OrderRejected() {
+	sql, err := fsb.Select().From(fsb.Table("users")).OrderA(struct{}{}).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).OrderA("name").Collate("C; DROP TABLE users").ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).NullsLast().ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT ORDER BY: no set order")
+}
+
+// Test_SelectString_Alias tests aliased columns, the table-qualified star and literals in the select list.
+func (s *SelectSuite) Test_SelectString_Alias() {
+	user := fsb.Table("users")
+	sb := fsb.Select(user.All(), user.Col("id").As("user_id"), fsb.Alias(fsb.Literal("member"), "kind")).From(user)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT users.*, users.id AS user_id, 'member' AS kind FROM users;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_ScalarSubquery tests a scalar subquery in the select list that is referred to by its alias.
+func (s *SelectSuite) Test_SelectString_ScalarSubquery() {
+	user := fsb.Table("users")
+	order := fsb.Table("orders")
+	orderCount := fsb.Alias(
+		fsb.Select("COUNT(*)").From(order).Where(fsb.Eq(order.Col("user_id"), user.Col("id"))),
+		"order_count",
+	)
+	sb := fsb.Select(user.Col("id"), orderCount).
+		From(user).
+		GroupBy(user.Col("id")).
+		Having(fsb.Gt(orderCount, 5)).
+		OrderDe(orderCount)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT users.id, (SELECT COUNT(*) FROM orders WHERE orders.user_id = users.id) AS order_count "+
+			"FROM users GROUP BY users.id HAVING order_count > 5 ORDER BY order_count DESC;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_AliasRejected tests that invalid aliases and unsupported select items are reported.
+func (s *SelectSuite) Test_SelectString_AliasRejected() {
+	sql, err := fsb.Select(fsb.Alias("id", "x; DROP TABLE users")).From(fsb.Table("users")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select(struct{}{}).From(fsb.Table("users")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_SelectString_JoinExpression tests that join conditions keep the structure of OR trees.
+func (s *SelectSuite) Test_SelectString_JoinExpression() {
+	user := fsb.Table("users")
+	token := fsb.Table("tokens")
+	sb := fsb.Select().
+		From(user).
+		InnerJoin(
+			token,
+			fsb.Eq(user.Col("id"), token.Col("user_id")),
+			fsb.Or(fsb.Eq(token.Col("kind"), "api"), fsb.IsNull(token.Col("revoked_at"))),
+		)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users INNER JOIN tokens ON users.id = tokens.user_id AND "+
+			"(tokens.kind = 'api' OR tokens.revoked_at IS NULL);",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_Using tests joins with a USING clause and NATURAL JOIN.
+func (s *SelectSuite) Test_SelectString_Using() {
+	sb := fsb.Select().
+		From(fsb.Table("users")).
+		LeftJoin(fsb.Table("profiles")).Using("user_id", "tenant_id").
+		NaturalJoin(fsb.Table("settings"))
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users LEFT JOIN profiles USING (user_id, tenant_id) NATURAL JOIN settings;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_UsingRejected tests invalid uses of USING.
+func (s *SelectSuite) Test_SelectString_UsingRejected() {
+	sql, err := fsb.Select().From(fsb.Table("users")).Using("id").ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT JOIN: no set join")
+
+	sql, err = fsb.Select().
+		From(fsb.Table("users")).
+		InnerJoin(fsb.Table("profiles"), fsb.Eq("users.id", fsb.Table("profiles").Col("user_id"))).
+		Using("user_id").
+		ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+
+	sql, err = fsb.Select().From(fsb.Table("users")).InnerJoin(fsb.Table("profiles")).Using("user_id").Dialect(fsb.SQLServer).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_SelectString_LateralJoin tests joins with correlated subqueries.
+func (s *SelectSuite) Test_SelectString_LateralJoin() {
+	user := fsb.Table("users")
+	order := fsb.Table("orders")
+	latest := fsb.Select().
+		From(order).
+		Where(fsb.Eq(order.Col("user_id"), user.Col("id"))).
+		OrderDe("created_at").
+		Limit(1)
+
+	sql, err := fsb.Select().From(user).LateralJoin(latest, "latest_order").Dialect(fsb.PostgreSQL).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users INNER JOIN LATERAL (SELECT * FROM orders WHERE orders.user_id = users.id "+
+			"ORDER BY created_at DESC LIMIT 1) AS latest_order ON true;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select().From(user).CrossJoinLateral(latest, "latest_order").ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users CROSS JOIN LATERAL (SELECT * FROM orders WHERE orders.user_id = users.id "+
+			"ORDER BY created_at DESC LIMIT 1) AS latest_order;",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select().From(user).CrossJoinLateral(latest, "latest_order").Dialect(fsb.SQLite).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.NotNil(s.T(), err)
+}
+
+// Test_SelectString_JoinFK tests joins whose conditions are derived from declared foreign keys.
+func (s *SelectSuite) Test_SelectString_JoinFK() {
+	user := fsb.Table("users")
+	order := fsb.Table("orders").ForeignKey("user_id", user.Col("id"))
+	item := fsb.Table("order_items").ForeignKey("order_id", order.Col("id"))
+
+	sql, err := fsb.Select().From(user).JoinFK(order).LeftJoinFK(item).ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"SELECT * FROM users INNER JOIN orders ON orders.user_id = users.id "+
+			"LEFT JOIN order_items ON order_items.order_id = orders.id;",
 		sql,
 	)
 	assert.Nil(s.T(), err)
+
+	sql, err = fsb.Select().From(order.As("o")).JoinFK(user.As("u")).ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM orders AS o INNER JOIN users AS u ON o.user_id = u.id;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_JoinFKRejected tests that missing and ambiguous relationships are reported.
+func (s *SelectSuite) Test_SelectString_JoinFKRejected() {
+	user := fsb.Table("users")
+	order := fsb.Table("orders").
+		ForeignKey("buyer_id", user.Col("id")).
+		ForeignKey("seller_id", user.Col("id"))
+
+	sql, err := fsb.Select().From(user).JoinFK(fsb.Table("tokens")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT JOIN: no foreign key between tokens and the tables of the statement")
+
+	sql, err = fsb.Select().From(user).JoinFK(order).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT JOIN: ambiguous foreign key between orders and the tables of the statement")
+}
+
+// Test_SelectString_SelfJoin tests a self-join with two aliases of the same table.
+func (s *SelectSuite) Test_SelectString_SelfJoin() {
+	user := fsb.Table("users")
+	a := user.As("a")
+	b := user.As("b")
+
+	sql, err := fsb.Select(a.Col("name"), b.Col("name")).
+		From(a).
+		InnerJoin(b, fsb.Eq(a.Col("manager_id"), b.Col("id"))).
+		ToSQL()
+
+	assert.Equal(s.T(), "SELECT a.name, b.name FROM users AS a INNER JOIN users AS b ON a.manager_id = b.id;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_SelectString_DuplicateAlias tests that tables sharing a name or alias are reported.
+func (s *SelectSuite) Test_SelectString_DuplicateAlias() {
+	user := fsb.Table("users")
+
+	sql, err := fsb.Select().From(user).InnerJoin(user, fsb.Eq("users.manager_id", user.Col("id"))).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT JOIN: duplicate table alias users")
+
+	sql, err = fsb.Select().From(user.As("u")).LeftJoin(fsb.Table("tokens").As("u")).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT JOIN: duplicate table alias u")
+}
+
+// Test_SelectString_Validate tests that invalid clauses are reported with the clause they belong to.
+func (s *SelectSuite) Test_SelectString_Validate() {
+	sql, err := fsb.Select().From(fsb.Table("users")).Having(fsb.Gt("COUNT(*)", 1)).Limit(-1).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "SELECT HAVING: HAVING requires GROUP BY\nSELECT LIMIT: negative limit -1")
+
+	err = fsb.Select().Validate()
+
+	var buildErr *fsb.BuildError
+	assert.True(s.T(), errors.As(err, &buildErr))
+	assert.Equal(s.T(), "FROM", buildErr.Clause)
+
+	sql, err = fsb.Select("1").ToSQL()
+
+	assert.Equal(s.T(), "SELECT 1;", sql)
+	assert.Nil(s.T(), err)
 }
 
 func TestSelectSuite(t *testing.T) {
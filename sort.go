@@ -0,0 +1,148 @@
+package fsb
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// SortSpec
+// SortSpec is the allowlist of public sort keys a client may send, such as "-created" or "name",
+// and the columns they sort on.
+// The names sent by the client are only used as lookup keys, so they never reach the generated SQL.
+type SortSpec struct {
+	keys       map[string]*ColumnContainer
+	tieBreaker *ColumnContainer
+}
+
+// SortKeyError
+// SortKeyError is returned when a client sends a sort key that is not registered in the SortSpec.
+// It matches ErrUnknownSort with errors.Is.
+type SortKeyError struct {
+	Key string
+}
+
+func (e *SortKeyError) Error() string {
+	return fmt.Sprintf("%s %q", ErrUnknownSort, e.Key)
+}
+
+func (e *SortKeyError) Unwrap() error {
+	return ErrUnknownSort
+}
+
+type sortOrder struct {
+	column *ColumnContainer
+	desc   bool
+}
+
+// SortKeys is a function that creates an empty SortSpec. Keys are registered with Key.
+func SortKeys() *SortSpec {
+	return &SortSpec{
+		keys: map[string]*ColumnContainer{},
+	}
+}
+
+// Key returns a copy of the SortSpec in which the public sort key name sorts on column.
+func (ss *SortSpec) Key(name string, column *ColumnContainer) *SortSpec {
+	c := ss.clone()
+	c.keys[name] = column
+
+	return c
+}
+
+// TieBreaker returns a copy of the SortSpec that appends column, usually the primary key, in ascending order
+// after the requested keys. This makes the order deterministic, which stable pagination depends on.
+// The column is not appended again when the client already sorts on it.
+func (ss *SortSpec) TieBreaker(column *ColumnContainer) *SortSpec {
+	c := ss.clone()
+	c.tieBreaker = column
+
+	return c
+}
+
+func (ss *SortSpec) clone() *SortSpec {
+	c := *ss
+
+	c.keys = make(map[string]*ColumnContainer, len(ss.keys))
+	for name, column := range ss.keys {
+		c.keys[name] = column
+	}
+
+	return &c
+}
+
+// Apply returns a copy of the SelectContainer ordered by the given sort parameters.
+// Each parameter may hold several comma separated keys, and a key prefixed with "-" sorts in descending order.
+// Unknown keys are rejected with a *SortKeyError, and the tie-breaker is appended when one is set.
+//
+// Example usage:
+//
+//	spec := SortKeys().Key("created", users.Col("created_at")).Key("name", users.Col("name")).TieBreaker(users.Col("id"))
+//	sel, err := spec.Apply(Select().From(users), "-created,name")
+//	// ORDER BY users.created_at DESC, users.name ASC, users.id ASC
+func (ss *SortSpec) Apply(s *SelectContainer, params ...string) (*SelectContainer, error) {
+	orders, err := ss.parse(params)
+	if err != nil {
+		return nil, err
+	}
+
+	return applySortOrders(s, orders), nil
+}
+
+// parse resolves the sort parameters into orders, collecting an error for every unknown key.
+func (ss *SortSpec) parse(params []string) ([]sortOrder, error) {
+	var orders []sortOrder
+	var errs []error
+
+	for _, param := range params {
+		for _, key := range strings.Split(param, ",") {
+			key = strings.TrimSpace(key)
+			if key == "" {
+				continue
+			}
+
+			desc := strings.HasPrefix(key, "-")
+			column, ok := ss.keys[strings.TrimPrefix(key, "-")]
+			if !ok {
+				errs = append(errs, &SortKeyError{Key: key})
+				continue
+			}
+
+			orders = append(orders, sortOrder{column: column, desc: desc})
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	if ss.tieBreaker != nil {
+		found := false
+		for _, order := range orders {
+			if *order.column == *ss.tieBreaker {
+				found = true
+			}
+		}
+
+		if !found {
+			orders = append(orders, sortOrder{column: ss.tieBreaker})
+		}
+	}
+
+	return orders, nil
+}
+
+// applySortOrders returns a copy of the SelectContainer with the orders appended.
+func applySortOrders(s *SelectContainer, orders []sortOrder) *SelectContainer {
+	c := s.Clone()
+
+	for _, order := range orders {
+		if order.desc {
+			c = c.OrderDe(order.column)
+		} else {
+			c = c.OrderA(order.column)
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,82 @@
+package fsb_test
+
+import (
+	"errors"
+	"fsb"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type SortSuite struct {
+	suite.Suite
+}
+
+func (s *SortSuite) spec() *fsb.SortSpec {
+	users := fsb.Table("users")
+
+	return fsb.SortKeys().
+		Key("created", users.Col("created_at")).
+		Key("name", users.Col("name")).
+		Key("id", users.Col("id")).
+		TieBreaker(users.Col("id"))
+}
+
+// Test_Apply tests that sort keys are mapped to their columns and the tie-breaker is appended.
+func (s *SortSuite) Test_Apply() {
+	sb, err := s.spec().Apply(fsb.Select().From(fsb.Table("users")), "-created,name")
+	assert.Nil(s.T(), err)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users ORDER BY users.created_at DESC, users.name ASC, users.id ASC;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ApplyTieBreakerRequested tests that the tie-breaker is not repeated when the client sorts on it.
+func (s *SortSuite) Test_ApplyTieBreakerRequested() {
+	sb, err := s.spec().Apply(fsb.Select().From(fsb.Table("users")), "-id")
+	assert.Nil(s.T(), err)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users ORDER BY users.id DESC;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ApplyEmpty tests that only the tie-breaker is used when no key is sent.
+func (s *SortSuite) Test_ApplyEmpty() {
+	sb, err := s.spec().Apply(fsb.Select().From(fsb.Table("users")))
+	assert.Nil(s.T(), err)
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "SELECT * FROM users ORDER BY users.id ASC;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_ApplyUnknown tests that keys that are not registered are rejected.
+func (s *SortSuite) Test_ApplyUnknown() {
+	sb, err := s.spec().Apply(fsb.Select().From(fsb.Table("users")), "name,-password;DROP TABLE users")
+
+	var keyErr *fsb.SortKeyError
+	assert.Nil(s.T(), sb)
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnknownSort))
+	assert.True(s.T(), errors.As(err, &keyErr))
+	assert.Equal(s.T(), "-password;DROP TABLE users", keyErr.Key)
+}
+
+// Test_KeyShared tests that adding keys does not change the SortSpec it was derived from.
+func (s *SortSuite) Test_KeyShared() {
+	base := fsb.SortKeys().Key("name", fsb.Table("users").Col("name"))
+	_ = base.Key("email", fsb.Table("users").Col("email"))
+
+	_, err := base.Apply(fsb.Select().From(fsb.Table("users")), "email")
+
+	assert.True(s.T(), errors.Is(err, fsb.ErrUnknownSort))
+}
+
+func TestSortSuite(t *testing.T) {
+	suite.Run(t, new(SortSuite))
+}
@@ -3,8 +3,15 @@ package fsb
 // TableContainer
 // テーブル関連情報構造体
 type TableContainer struct {
-	name  string
-	bName string
+	name        string
+	bName       string
+	foreignKeys []foreignKey
+}
+
+// foreignKey is a relationship declared with ForeignKey: column of the table references ref.
+type foreignKey struct {
+	column string
+	ref    *ColumnContainer
 }
 
 type ColumnContainer struct {
@@ -21,12 +28,40 @@ func Table(t string) *TableContainer {
 	}
 }
 
-// As is a method of TableContainer that sets the value of the `name` field to the provided `tName` value.
-// It returns a pointer to the modified TableContainer instance.
+// As is a method of TableContainer that returns a copy of the table whose `name` field is set to the provided `tName` value.
+// The original TableContainer is left untouched, so one table can be aliased several times.
 func (t *TableContainer) As(tName string) *TableContainer {
-	t.name = tName
+	c := t.clone()
+	c.name = tName
+
+	return c
+}
+
+// clone returns a copy of the TableContainer. It returns nil for a nil table.
+func (t *TableContainer) clone() *TableContainer {
+	if t == nil {
+		return nil
+	}
+
+	c := *t
+	c.foreignKeys = append([]foreignKey(nil), t.foreignKeys...)
+
+	return &c
+}
 
-	return t
+// ForeignKey returns a copy of the table that declares column as a foreign key referencing ref,
+// such as orders.user_id referencing users.id. JoinFK and LeftJoinFK derive join conditions from it.
+// ref should be taken from the table without an alias, because the relationship is matched by table name.
+//
+// Example usage:
+//
+//	users := Table("users")
+//	orders := Table("orders").ForeignKey("user_id", users.Col("id"))
+func (t *TableContainer) ForeignKey(column string, ref *ColumnContainer) *TableContainer {
+	c := t.clone()
+	c.foreignKeys = append(c.foreignKeys, foreignKey{column: column, ref: ref})
+
+	return c
 }
 
 func (t *TableContainer) Col(col string) *ColumnContainer {
@@ -35,3 +70,24 @@ func (t *TableContainer) Col(col string) *ColumnContainer {
 		col:   col,
 	}
 }
+
+// Name returns the name of the column.
+func (c *ColumnContainer) Name() string {
+	return c.col
+}
+
+// TableName returns the table name or alias the column belongs to.
+// It returns an empty string for columns that were given as a plain name.
+func (c *ColumnContainer) TableName() string {
+	return c.tName
+}
+
+// All returns the table-qualified star of the table, rendered as users.* in the select list.
+func (t *TableContainer) All() *ColumnContainer {
+	return t.Col("*")
+}
+
+// As returns the column with an alias for the select list, such as users.id AS user_id.
+func (c *ColumnContainer) As(alias string) *AliasContainer {
+	return Alias(c, alias)
+}
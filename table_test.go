@@ -42,6 +42,18 @@ func (s *TableSuite) Test_SetTable() {
 	assert.Equal(s.T(), "users", *ps)
 }
 
+// Test_AsCopy tests that As returns a new table and leaves the original table untouched.
+func (s *TableSuite) Test_AsCopy() {
+	users := fsb.Table("users")
+	a := users.As("a")
+	b := users.As("b")
+
+	assert.NotSame(s.T(), a, b)
+	assert.Equal(s.T(), "a", a.Col("id").TableName())
+	assert.Equal(s.T(), "b", b.Col("id").TableName())
+	assert.Equal(s.T(), "users", users.Col("id").TableName())
+}
+
 func TestTableSuite(t *testing.T) {
 	suite.Run(t, new(TableSuite))
 }
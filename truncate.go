@@ -19,20 +19,38 @@ func Truncate(table *TableContainer) *TruncateContainer {
 	}
 }
 
-func (t *TruncateContainer) ToSQL() (string, error) {
-	if len(t.errs) > 0 {
-		return "", errors.Join(t.errs...)
+// Clone returns a deep copy of the TruncateContainer.
+func (t *TruncateContainer) Clone() *TruncateContainer {
+	c := *t
+
+	c.table = t.table.clone()
+	c.errs = append([]error(nil), t.errs...)
+
+	return &c
+}
+
+// Validate checks the structure of the TRUNCATE TABLE statement without generating it.
+// It returns the errors recorded by the builder methods together with a *BuildError
+// when the table is missing or aliased, because TRUNCATE TABLE does not accept an alias.
+// ToSQL calls Validate before generating the statement.
+func (t *TruncateContainer) Validate() error {
+	errs := append([]error(nil), t.errs...)
+
+	if t.table == nil || t.table.name == "" {
+		errs = append(errs, buildError("TRUNCATE", "TABLE", CodeMissingClause, "no table set"))
+	} else if t.table.name != t.table.bName {
+		errs = append(errs, buildError("TRUNCATE", "TABLE", CodeInvalidClause, "alias %s is not allowed", t.table.name))
 	}
 
-	sqlElements := []string{"TRUNCATE TABLE"}
+	return errors.Join(errs...)
+}
 
-	if t.table != nil {
-		if t.table.name != t.table.bName {
-			sqlElements = append(sqlElements, t.table.bName, "AS", t.table.name)
-		} else {
-			sqlElements = append(sqlElements, t.table.name)
-		}
+func (t *TruncateContainer) ToSQL() (string, error) {
+	if err := t.Validate(); err != nil {
+		return "", err
 	}
 
+	sqlElements := []string{"TRUNCATE TABLE", t.table.bName}
+
 	return fmt.Sprintf("%s;", strings.Join(sqlElements, " ")), nil
 }
@@ -19,6 +19,14 @@ func (s *TruncateSuite) Test_Truncate() {
 	assert.Nil(s.T(), err)
 }
 
+// Test_TruncateValidate tests that an aliased table is rejected.
+func (s *TruncateSuite) Test_TruncateValidate() {
+	sql, err := fsb.Truncate(fsb.Table("users").As("u")).ToSQL()
+
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "TRUNCATE TABLE: alias u is not allowed")
+}
+
 func TestTruncateSuite(t *testing.T) {
 	suite.Run(t, new(TruncateSuite))
 }
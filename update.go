@@ -3,97 +3,266 @@ package fsb
 import (
 	"errors"
 	"fmt"
+	"sort"
 	"strings"
 )
 
 type UpdateContainer struct {
-	fields map[string]interface{}
-	table  *TableContainer
-	where  *Expression
-	errs   []error
+	fields   []assignment
+	table    *TableContainer
+	where    *Expression
+	allRows  bool
+	dialect  Dialect
+	encoders *Encoders
+	errs     []error
+}
+
+// assignment is an item of the SET clause. It has several columns for a tuple assignment such as (a, b) = (...).
+type assignment struct {
+	columns []string
+	value   interface{}
 }
 
 // Update is a function that creates a new UpdateContainer object.
 // It takes a pointer to a TableContainer as input and returns a pointer to an UpdateContainer.
-// The UpdateContainer object is initialized with the provided TableContainer and an empty "fields" map.
-// The fields map will be used to store the fields and their respective values to be updated.
+// The UpdateContainer object is initialized with the provided TableContainer and an empty list of fields.
+// The fields are rendered in the SET clause in the order they were set.
 func Update(table *TableContainer) *UpdateContainer {
 	return &UpdateContainer{
 		table:  table,
-		fields: make(map[string]interface{}),
+		fields: []assignment{},
 	}
 }
 
-// Set is a method of UpdateContainer that sets the value of a column in the fields map.
-// It takes two parameters, column and value, and returns a pointer to the UpdateContainer.
+// Set is a method of UpdateContainer that sets the value of a column.
+// It takes two parameters, column and value, and returns a pointer to a copy of the UpdateContainer.
 // The column parameter can either be a string or a *ColumnContainer.
+// Columns are rendered in the order they were first set; setting a column again replaces its value.
+// Besides values, the value can be nil for NULL, Default, a column, a function call or a *SelectContainer.
 func (u *UpdateContainer) Set(column, value interface{}) *UpdateContainer {
-	var c string
+	cu := u.Clone()
 
-	switch v := column.(type) {
-	case string:
-		c = v
-	case *ColumnContainer:
-		c = fmt.Sprintf("%s.%s", v.tName, v.col)
+	c, err := updateColumn(column)
+	if err != nil {
+		cu.errs = append(cu.errs, err)
+		return cu
+	}
+
+	for i, field := range cu.fields {
+		if len(field.columns) == 1 && field.columns[0] == c {
+			cu.fields[i].value = value
+			return cu
+		}
+	}
+
+	cu.fields = append(cu.fields, assignment{columns: []string{c}, value: value})
+
+	return cu
+}
+
+// SetTuple is a method of UpdateContainer that assigns several columns at once,
+// such as (a, b) = (SELECT x, y FROM ...). The value is a *SelectContainer or a slice with one value per column.
+func (u *UpdateContainer) SetTuple(columns []interface{}, value interface{}) *UpdateContainer {
+	cu := u.Clone()
+
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		c, err := updateColumn(column)
+		if err != nil {
+			cu.errs = append(cu.errs, err)
+			return cu
+		}
+		names[i] = c
+	}
+
+	if values, ok := value.([]interface{}); ok {
+		if len(values) != len(names) {
+			cu.errs = append(cu.errs, buildError("UPDATE", "SET", CodeInvalidValue, "tuple needs %d values but got %d", len(names), len(values)))
+			return cu
+		}
+		value = rowValue(values)
 	}
 
-	u.fields[c] = value
+	cu.fields = append(cu.fields, assignment{columns: names, value: value})
 
-	return u
+	return cu
 }
 
-// SetMap is a method of UpdateContainer that sets the fields map to the given vmap.
+// SetMap is a method of UpdateContainer that sets the fields to the given vmap.
 // It takes a single parameter, vmap, which is a map[string]interface{}.
-// It returns a pointer to the UpdateContainer.
+// The columns are rendered in sorted order, so the generated SQL is the same on every run.
+// The map is copied, so later changes to vmap do not affect the statement.
+// It returns a pointer to a copy of the UpdateContainer.
 func (u *UpdateContainer) SetMap(vmap map[string]interface{}) *UpdateContainer {
-	u.fields = vmap
+	columns := make([]string, 0, len(vmap))
+	for column := range vmap {
+		columns = append(columns, column)
+	}
+	sort.Strings(columns)
 
-	return u
+	c := u.Clone()
+	c.fields = make([]assignment, len(columns))
+	for i, column := range columns {
+		c.fields[i] = assignment{columns: []string{column}, value: vmap[column]}
+	}
+
+	return c
+}
+
+// updateColumn returns the name of a column of the SET clause.
+func updateColumn(column interface{}) (string, error) {
+	switch v := column.(type) {
+	case string:
+		return v, nil
+	case *ColumnContainer:
+		return fmt.Sprintf("%s.%s", v.tName, v.col), nil
+	default:
+		return "", buildError("UPDATE", "SET", CodeInvalidValue, "unsupported column type %T", column)
+	}
 }
 
 // Where
-// It sets the WHERE clause of the SQL SELECT statement.
+// It sets the WHERE clause of the SQL UPDATE statement.
 func (u *UpdateContainer) Where(conditions *Expression) *UpdateContainer {
-	u.where = conditions
+	c := u.Clone()
+	c.where = conditions
+
+	return c
+}
+
+// AndWhere adds the conditions to the WHERE clause of the SQL UPDATE statement with the AND operator.
+// When no WHERE clause has been set yet, the conditions become the WHERE clause.
+// A nil expression is ignored, so optional filters can be chained directly.
+func (u *UpdateContainer) AndWhere(conditions *Expression) *UpdateContainer {
+	c := u.Clone()
+	c.where = And(c.where, conditions)
+
+	return c
+}
+
+// OrWhere adds the conditions to the WHERE clause of the SQL UPDATE statement with the OR operator.
+// A nil expression is ignored in the same way as AndWhere.
+func (u *UpdateContainer) OrWhere(conditions *Expression) *UpdateContainer {
+	c := u.Clone()
+	c.where = Or(c.where, conditions)
+
+	return c
+}
+
+// WhereIf adds the conditions to the WHERE clause with the AND operator only when cond is true.
+func (u *UpdateContainer) WhereIf(cond bool, conditions *Expression) *UpdateContainer {
+	if !cond {
+		return u.Clone()
+	}
+
+	return u.AndWhere(conditions)
+}
+
+// Encoders is a method of UpdateContainer that sets the registry of encoders used for the values of the statement.
+// Without it, DefaultEncoders is used.
+func (u *UpdateContainer) Encoders(encoders *Encoders) *UpdateContainer {
+	c := u.Clone()
+	c.encoders = encoders
+
+	return c
+}
+
+// Dialect is a method of UpdateContainer that sets the database the SQL update statement is generated for.
+// Values and conditions whose syntax differs between databases are rendered for this dialect.
+func (u *UpdateContainer) Dialect(d Dialect) *UpdateContainer {
+	c := u.Clone()
+	c.dialect = d
+
+	return c
+}
+
+// AllRows allows the statement to update every row of the table.
+// Without it, Validate rejects an UPDATE without a WHERE clause or with a condition that is always true, such as 1 = 1.
+func (u *UpdateContainer) AllRows() *UpdateContainer {
+	c := u.Clone()
+	c.allRows = true
+
+	return c
+}
+
+// Clone returns a deep copy of the UpdateContainer.
+// Every builder method works on a clone, so an UpdateContainer can be shared and extended safely.
+func (u *UpdateContainer) Clone() *UpdateContainer {
+	c := *u
+
+	c.table = u.table.clone()
+	c.where = u.where.Clone()
+	c.errs = append([]error(nil), u.errs...)
+	c.fields = make([]assignment, len(u.fields))
+	for i, field := range u.fields {
+		c.fields[i] = assignment{columns: append([]string(nil), field.columns...), value: field.value}
+	}
 
-	return u
+	return &c
+}
+
+// Validate is a method of UpdateContainer that checks the structure of the SQL update statement without generating it.
+// It returns the errors recorded by the builder methods together with a *BuildError
+// when the table or the columns to set are missing, or when the statement would update every row without AllRows.
+// ToSQL reports the same errors together with the ones found while rendering SET and WHERE.
+func (u *UpdateContainer) Validate() error {
+	return errors.Join(u.validate()...)
+}
+
+// validate returns the errors recorded by the builder methods and the structural errors of the statement.
+func (u *UpdateContainer) validate() []error {
+	errs := append([]error(nil), u.errs...)
+
+	if u.table == nil || u.table.name == "" {
+		errs = append(errs, buildError("UPDATE", "TABLE", CodeMissingClause, "no table set"))
+	}
+
+	if len(u.fields) == 0 {
+		errs = append(errs, buildError("UPDATE", "SET", CodeMissingClause, "no columns to set"))
+	}
+
+	if err := checkUnbounded("UPDATE", u.where, u.allRows); err != nil {
+		errs = append(errs, err)
+	}
+
+	return errs
 }
 
 // ToSQL is a method of UpdateContainer that generates a SQL statement for an update operation.
 // It returns the SQL statement as a string and an error if there are any errors.
-// It first checks if there are any errors stored in the UpdateContainer.
-// If there are, it joins the errors and returns them.
+// The errors of Validate and the ones found while rendering SET and WHERE are joined and returned together.
 // Then, it starts building the SQL statement by adding the "UPDATE" keyword.
 func (u *UpdateContainer) ToSQL() (string, error) {
-	if len(u.errs) > 0 {
-		return "", errors.Join(u.errs...)
-	}
-
+	r := &renderer{dialect: u.dialect, encoders: u.encoders, statement: "UPDATE", clause: "SET", errs: u.validate()}
 	sqlElements := []string{"UPDATE"}
 
-	if u.table != nil {
-		if u.table.name != u.table.bName {
-			sqlElements = append(sqlElements, u.table.bName, "AS", u.table.name)
-		} else {
-			sqlElements = append(sqlElements, u.table.name)
-		}
-	} else {
-		u.errs = append(u.errs, fmt.Errorf("no set Table"))
-		return "", fmt.Errorf("no set Table")
+	if u.table != nil && u.table.name != u.table.bName {
+		sqlElements = append(sqlElements, u.table.bName, "AS", u.table.name)
+	} else if u.table != nil {
+		sqlElements = append(sqlElements, u.table.name)
 	}
 
 	sqlElements = append(sqlElements, "SET")
 
 	var setValues []string
 
-	for column, value := range u.fields {
-		setValues = append(setValues, fmt.Sprintf("%s = %s", column, ConvertColumn(value, false)))
+	for _, field := range u.fields {
+		column := field.columns[0]
+		if len(field.columns) > 1 {
+			column = fmt.Sprintf("(%s)", strings.Join(field.columns, ", "))
+		}
+		setValues = append(setValues, fmt.Sprintf("%s = %s", column, r.value(field.value)))
 	}
 
 	sqlElements = append(sqlElements, strings.Join(setValues, ", "))
 
 	if u.where != nil {
-		sqlElements = append(sqlElements, "WHERE", u.where.condition)
+		r.clause = "WHERE"
+		sqlElements = append(sqlElements, "WHERE", r.expression(u.where))
+	}
+
+	if len(r.errs) > 0 {
+		return "", errors.Join(r.errs...)
 	}
 
 	return fmt.Sprintf("%s;", strings.Join(sqlElements, " ")), nil
@@ -1,6 +1,7 @@
 package fsb_test
 
 import (
+	"errors"
 	"fsb"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -13,7 +14,7 @@ type UpdateSuite struct {
 
 // Test_Update is a test function that tests the Update method of UpdateSuite.
 func (s *UpdateSuite) Test_Update() {
-	sb := fsb.Update(fsb.Table("users")).Set("id", 1)
+	sb := fsb.Update(fsb.Table("users")).AllRows().Set("id", 1)
 	sql, err := sb.ToSQL()
 
 	assert.Equal(s.T(), "UPDATE users SET id = 1;", sql)
@@ -22,7 +23,7 @@ func (s *UpdateSuite) Test_Update() {
 
 // Test_UpdateMulti is a test function that tests the UpdateMulti method of UpdateSuite.
 func (s *UpdateSuite) Test_UpdateMulti() {
-	sb := fsb.Update(fsb.Table("users")).Set("name", "test").Set("id", 1)
+	sb := fsb.Update(fsb.Table("users")).AllRows().Set("name", "test").Set("id", 1)
 	sql, err := sb.ToSQL()
 
 	assert.Equal(s.T(), "UPDATE users SET name = 'test', id = 1;", sql)
@@ -35,7 +36,7 @@ func (s *UpdateSuite) Test_UpdateSetMap() {
 	vmap["id"] = 1
 	vmap["name"] = "test"
 
-	sb := fsb.Update(fsb.Table("users")).SetMap(vmap)
+	sb := fsb.Update(fsb.Table("users")).AllRows().SetMap(vmap)
 	sql, err := sb.ToSQL()
 
 	assert.Equal(s.T(), "UPDATE users SET id = 1, name = 'test';", sql)
@@ -51,6 +52,110 @@ func (s *UpdateSuite) Test_UpdateWhere() {
 	assert.Nil(s.T(), err)
 }
 
+// Test_UpdateShared is a test function that checks a shared UpdateContainer is not changed by later calls.
+func (s *UpdateSuite) Test_UpdateShared() {
+	base := fsb.Update(fsb.Table("users")).AllRows().Set("name", "test")
+	_ = base.Where(fsb.Eq("id", 1))
+
+	sql, err := base.ToSQL()
+
+	assert.Equal(s.T(), "UPDATE users SET name = 'test';", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_UpdateAndWhere is a test function that checks AndWhere accumulates the WHERE conditions.
+func (s *UpdateSuite) Test_UpdateAndWhere() {
+	sb := fsb.Update(fsb.Table("users")).
+		Set("name", "test").
+		AndWhere(fsb.Eq("id", 1)).
+		AndWhere(fsb.EqIfNotZero("age", 0)).
+		WhereIf(true, fsb.Eq("deleted", false))
+	sql, err := sb.ToSQL()
+
+	assert.Equal(s.T(), "UPDATE users SET name = 'test' WHERE id = 1 AND deleted = false;", sql)
+	assert.Nil(s.T(), err)
+}
+
+// Test_UpdateOrder is a test function that checks the SET clause keeps the order in which columns were set.
+func (s *UpdateSuite) Test_UpdateOrder() {
+	sb := fsb.Update(fsb.Table("users")).AllRows().Set("name", "test").Set("id", 1).Set("age", 2).Set("name", "other")
+
+	for i := 0; i < 10; i++ {
+		sql, err := sb.ToSQL()
+
+		assert.Equal(s.T(), "UPDATE users SET name = 'other', id = 1, age = 2;", sql)
+		assert.Nil(s.T(), err)
+	}
+}
+
+// Test_UpdateSpecialValues is a test function that checks NULL, DEFAULT, subqueries and tuple assignments.
+func (s *UpdateSuite) Test_UpdateSpecialValues() {
+	profile := fsb.Table("profiles")
+	latest := fsb.Select(profile.Col("name"), profile.Col("email")).
+		From(profile).
+		Where(fsb.Eq(profile.Col("user_id"), fsb.Table("users").Col("id")))
+
+	sb := fsb.Update(fsb.Table("users")).AllRows().
+		Set("deleted_at", nil).
+		Set("status", fsb.Default).
+		Set("score", fsb.Select("MAX(score)").From(fsb.Table("scores"))).
+		SetTuple([]interface{}{"name", "email"}, latest).
+		SetTuple([]interface{}{"x", "y"}, []interface{}{1, 2})
+
+	sql, err := sb.ToSQL()
+
+	assert.Equal(
+		s.T(),
+		"UPDATE users SET deleted_at = NULL, status = DEFAULT, score = (SELECT MAX(score) FROM scores), "+
+			"(name, email) = (SELECT profiles.name, profiles.email FROM profiles WHERE profiles.user_id = users.id), "+
+			"(x, y) = (1, 2);",
+		sql,
+	)
+	assert.Nil(s.T(), err)
+}
+
+// Test_UpdateSetRejected is a test function that checks invalid SET items are reported.
+func (s *UpdateSuite) Test_UpdateSetRejected() {
+	sql, err := fsb.Update(fsb.Table("users")).AllRows().Set("name", "test").Set(1, "test").ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "UPDATE SET: unsupported column type int")
+
+	sql, err = fsb.Update(fsb.Table("users")).AllRows().
+		Set("name", "test").
+		SetTuple([]interface{}{"x", "y"}, []interface{}{1}).
+		ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "UPDATE SET: tuple needs 2 values but got 1")
+}
+
+// Test_UpdateValidate is a test function that checks a statement without table or columns is rejected.
+func (s *UpdateSuite) Test_UpdateValidate() {
+	err := fsb.Update(nil).AllRows().Validate()
+
+	var buildErr *fsb.BuildError
+	assert.True(s.T(), errors.As(err, &buildErr))
+	assert.Equal(s.T(), "TABLE", buildErr.Clause)
+	assert.EqualError(s.T(), err, "UPDATE TABLE: no table set\nUPDATE SET: no columns to set")
+}
+
+// Test_UpdateUnbounded is a test function that checks updating every row requires AllRows.
+func (s *UpdateSuite) Test_UpdateUnbounded() {
+	sql, err := fsb.Update(fsb.Table("users")).Set("active", false).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "UPDATE WHERE: missing WHERE clause; use AllRows to change every row")
+
+	sql, err = fsb.Update(fsb.Table("users")).Set("active", false).Where(fsb.Eq(1, 1)).ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "UPDATE WHERE: condition is always true; use AllRows to change every row")
+
+	sql, err = fsb.Update(fsb.Table("users")).
+		Set("active", false).
+		Where(fsb.Eq("tenant_id", 3).OR(fsb.Eq(fsb.Table("users").Col("id"), fsb.Table("users").Col("id")).AND(fsb.Eq("1", 1)))).
+		ToSQL()
+	assert.Equal(s.T(), "", sql)
+	assert.EqualError(s.T(), err, "UPDATE WHERE: condition is always true; use AllRows to change every row")
+}
+
 func TestUpdateSuite(t *testing.T) {
 	suite.Run(t, new(UpdateSuite))
 }
\ No newline at end of file
@@ -0,0 +1,195 @@
+package fsb
+
+import (
+	"database/sql/driver"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Default is a value that assigns the default value of the column in SET and VALUES, rendered as DEFAULT.
+//
+// Example usage:
+//
+//	Update(Table("users")).Set("status", Default) // UPDATE users SET status = DEFAULT;
+var Default = sqlKeyword("DEFAULT")
+
+// sqlKeyword is a value rendered as a bare SQL keyword instead of a literal.
+type sqlKeyword string
+
+// formatValue renders a value as a SQL literal for the given dialect.
+// It accepts the types database/sql can send to a driver, pointers to them, types with such an underlying kind,
+// and any driver.Valuer such as sql.NullString. Nil, nil pointers and NULL Valuers are rendered as NULL.
+// Types registered in enc are converted by their encoder first.
+func formatValue(v interface{}, d Dialect, enc *Encoders) (string, error) {
+	if encoded, ok, err := enc.encode(v); ok {
+		if err != nil {
+			return "", err
+		}
+		return formatValue(encoded, d, enc)
+	}
+
+	switch t := v.(type) {
+	case nil:
+		return "NULL", nil
+	case driver.Valuer:
+		if rv := reflect.ValueOf(t); rv.Kind() == reflect.Ptr && rv.IsNil() {
+			return "NULL", nil
+		}
+		dv, err := t.Value()
+		if err != nil {
+			return "", fmt.Errorf("invalid value %T: %w", v, err)
+		}
+		return formatValue(dv, d, enc)
+	case string:
+		return quoteString(t, d), nil
+	case []byte:
+		if t == nil {
+			return "NULL", nil
+		}
+		return formatBytes(t, d), nil
+	case bool:
+		return formatBool(t, d), nil
+	case int:
+		return strconv.Itoa(t), nil
+	case int64:
+		return strconv.FormatInt(t, 10), nil
+	case float64:
+		return formatFloat(t, 64)
+	case time.Time:
+		return formatTime(t, d), nil
+	case *ColumnContainer:
+		return ConvertColumn(t, true), nil
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return "NULL", nil
+		}
+		return formatValue(rv.Elem().Interface(), d, enc)
+	case reflect.String:
+		return quoteString(rv.String(), d), nil
+	case reflect.Bool:
+		return formatBool(rv.Bool(), d), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(rv.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(rv.Uint(), 10), nil
+	case reflect.Float32:
+		return formatFloat(rv.Float(), 32)
+	case reflect.Float64:
+		return formatFloat(rv.Float(), 64)
+	case reflect.Slice:
+		if rv.Type().Elem().Kind() == reflect.Uint8 {
+			if rv.IsNil() {
+				return "NULL", nil
+			}
+			return formatBytes(rv.Bytes(), d), nil
+		}
+	}
+
+	return "", fmt.Errorf("unsupported value type %T", v)
+}
+
+// isNullValue reports whether the value is rendered as NULL with the encoders of enc, which may be nil.
+func isNullValue(v interface{}, enc *Encoders) bool {
+	if v == nil {
+		return true
+	}
+
+	if encoded, ok, err := enc.encode(v); ok {
+		return err == nil && isNullValue(encoded, enc)
+	}
+
+	rv := reflect.ValueOf(v)
+	if (rv.Kind() == reflect.Ptr || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return true
+	}
+
+	if valuer, ok := v.(driver.Valuer); ok {
+		dv, err := valuer.Value()
+		return err == nil && dv == nil
+	}
+
+	if rv.Kind() == reflect.Ptr {
+		return isNullValue(rv.Elem().Interface(), enc)
+	}
+
+	return false
+}
+
+// quoteString renders a string literal, doubling the quotes inside it.
+// MySQL also treats the backslash as an escape character in string literals, so it is doubled there.
+func quoteString(s string, d Dialect) string {
+	if d == MySQL {
+		s = strings.ReplaceAll(s, `\`, `\\`)
+	}
+
+	return fmt.Sprintf("'%s'", strings.ReplaceAll(s, "'", "''"))
+}
+
+// formatBool renders a boolean. SQL Server has no boolean literals, so 1 and 0 are used there.
+func formatBool(b bool, d Dialect) string {
+	if d == SQLServer {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+
+	if b {
+		return "true"
+	}
+
+	return "false"
+}
+
+// formatFloat renders a floating point number with the fewest digits that represent it exactly.
+func formatFloat(f float64, bitSize int) (string, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return "", fmt.Errorf("unsupported float value %v", f)
+	}
+
+	return strconv.FormatFloat(f, 'g', -1, bitSize), nil
+}
+
+// formatBytes renders a binary string literal in the syntax of the dialect.
+func formatBytes(b []byte, d Dialect) string {
+	switch d {
+	case PostgreSQL:
+		return fmt.Sprintf("'\\x%s'", hex.EncodeToString(b))
+	case SQLServer:
+		return fmt.Sprintf("0x%s", hex.EncodeToString(b))
+	default:
+		return fmt.Sprintf("X'%s'", hex.EncodeToString(b))
+	}
+}
+
+// formatTime renders a timestamp literal.
+// MySQL, SQLite and SQL Server do not accept a time zone offset, so the time is written in its own location there.
+func formatTime(t time.Time, d Dialect) string {
+	switch d {
+	case MySQL, SQLite, SQLServer:
+		return quoteString(t.Format("2006-01-02 15:04:05.999999"), d)
+	default:
+		return quoteString(t.Format("2006-01-02 15:04:05.999999Z07:00"), d)
+	}
+}
+
+// literal renders a value on the right-hand side of a predicate or in VALUES and SET,
+// and reports values that cannot be rendered instead of dropping them.
+func (r *renderer) literal(v interface{}) string {
+	valueStr, err := formatValue(v, r.dialect, r.registry())
+	if err != nil {
+		r.fail(CodeInvalidValue, "%w", err)
+		return ""
+	}
+
+	return valueStr
+}